@@ -0,0 +1,30 @@
+/* Areas.go
+2024, cdfisher
+----------------
+Loads area (world map element) definitions, which objects refer to by map_area_id for their
+minimap icons, into the areas table.
+
+field3296 is read as a 24-bit RGB colour, used for the area's label, and field3298 as five strings
+that are the area's menu options, encoded like NPC and object actions. The dump's other unnamed fields
+(field3294, field3297, field3308, field3310) have no known purpose and aren't loaded.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertAreaData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "area_defs", func(def AreaEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO areas (id, name, sprite_id, text_color, options, category) VALUES (?, ?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.Name, def.SpriteID, def.Field3296, SliceTextStr(def.Field3298),
+			def.Category)
+		if err != nil {
+			fmt.Printf("Error inserting area %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
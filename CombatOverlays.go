@@ -0,0 +1,45 @@
+/* CombatOverlays.go
+2024, cdfisher
+----------------
+Loads hitsplat and healthbar definitions into the hitsplats and healthbars tables.
+
+Healthbar columns follow the dump's keys, which are the field names of RuneLite's HealthBarDefinition
+with the healthBar prefix dropped. The purpose of field3272, field3275, field3276, field3277,
+field3278, and field3283 is unknown, so they are stored as-is in field_<n> columns.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertHitsplatData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "hitsplat_defs", func(def HitsplatEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO hitsplats (id, string_format, font_type, text_color, text_offset_y, left_sprite, left_sprite_2, right_sprite_id, background_sprite, use_damage, display_cycles, fade_start_cycle, scroll_to_offset_x, scroll_to_offset_y, varbit_id, varp_id, multihitsplats) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.StringFormat, def.FontType, def.TextColor, def.TextOffsetY,
+			def.LeftSprite, def.LeftSprite2, def.RightSpriteID, def.BackgroundSprite, def.UseDamage, def.DisplayCycles,
+			def.FadeStartCycle, def.ScrollToOffsetX, def.ScrollToOffsetY, def.VarbitID, def.VarpID,
+			SliceTextInt(def.MultiHitsplats))
+		if err != nil {
+			fmt.Printf("Error inserting hitsplat %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
+
+func insertHealthBarData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "healthbar_defs", func(def HealthBarEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO healthbars (id, front_sprite_id, back_sprite_id, health_scale, padding, field_3276, field_3277, field_3278, field_3283, field_3272, field_3275) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.HealthBarFrontSpriteID, def.HealthBarBackSpriteID,
+			def.HealthScale, def.HealthBarPadding, def.Field3276, def.Field3277, def.Field3278, def.Field3283,
+			def.Field3272, def.Field3275)
+		if err != nil {
+			fmt.Printf("Error inserting healthbar %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
@@ -0,0 +1,99 @@
+/* DBTables.go
+2024, cdfisher
+----------------
+Loads dbtable and dbrow definitions. The cache doesn't name columns, so dbtable_columns only has
+names if the dump provides them.
+
+Each column of a row holds a list of values made up of one or more tuples matching the column's
+types, e.g. a column typed [OBJ, INTEGER] holding [4151, 1, 4153, 2] is two (item, count) tuples.
+Values are stored flattened in dbrow_values with their index into that list.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+func insertDBTableData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "dbtable_defs", func(def DBTableEntry, fileName string) {
+		err := batch.exec("INSERT OR REPLACE INTO dbtables (id, column_count) VALUES (?, ?)", def.ID,
+			len(def.Types))
+		if err != nil {
+			fmt.Printf("Error inserting dbtable %s : %s\n", fileName, err)
+			return
+		}
+
+		statement := "INSERT OR REPLACE INTO dbtable_columns (table_id, column_id, name, types, default_values) VALUES (?, ?, ?, ?, ?)"
+		for column, types := range def.Types {
+			// Columns can be missing from the middle of a table
+			if types == nil {
+				continue
+			}
+			var name any
+			if column < len(def.ColumnNames) && def.ColumnNames[column] != "" {
+				name = def.ColumnNames[column]
+			}
+			var defaults []interface{}
+			if column < len(def.DefaultColumnValues) {
+				defaults = def.DefaultColumnValues[column]
+			}
+			defaultsStr, err := json.Marshal(defaults)
+			if err != nil {
+				fmt.Printf("Error marshalling defaults for column %d of dbtable %s : %s\n", column, fileName, err)
+			}
+
+			err = batch.exec(statement, def.ID, column, name, SliceTextStr(types), string(defaultsStr))
+			if err != nil {
+				fmt.Printf("Error inserting column %d of dbtable %s : %s\n", column, fileName, err)
+			}
+		}
+	})
+	batch.commit()
+}
+
+func insertDBRowData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "dbrow_defs", func(def DBRowEntry, fileName string) {
+		err := batch.exec("INSERT OR REPLACE INTO dbrows (id, table_id) VALUES (?, ?)", def.ID, def.TableID)
+		if err != nil {
+			fmt.Printf("Error inserting dbrow %s : %s\n", fileName, err)
+			return
+		}
+
+		statement := "INSERT OR REPLACE INTO dbrow_values (row_id, table_id, column_id, value_index, type, int_value, string_value) VALUES (?, ?, ?, ?, ?, ?, ?)"
+		for column, values := range def.ColumnValues {
+			var types []string
+			if column < len(def.ColumnTypes) {
+				types = def.ColumnTypes[column]
+			}
+
+			for i, val := range values {
+				var varType any
+				if len(types) > 0 {
+					varType = types[i%len(types)]
+				}
+
+				var intVal, stringVal any
+				switch v := val.(type) {
+				case float64:
+					intVal = int64(v)
+				case string:
+					stringVal = v
+				default:
+					// Stored as NULL rather than skipped so later values keep their index within the column
+					fmt.Printf("Unknown value type in column %d of dbrow %s : %v\n", column, fileName, val)
+				}
+
+				err = batch.exec(statement, def.ID, def.TableID, column, i, varType, intVal, stringVal)
+				if err != nil {
+					fmt.Printf("Error inserting column %d of dbrow %s : %s\n", column, fileName, err)
+				}
+			}
+		}
+	})
+	batch.commit()
+}
@@ -13,7 +13,7 @@ cache 221.7 (2024-05-15-rev221)
 Entries TODOs:
 ----------------
 - Add handling for unknown keys
-- Add support for other objects in cache: dbtables, param_defs, ???
+- Add support for other objects in cache: ???
 - Update Params fields to use generics or reflection rather than interface{}
 - Reorder fields to match appearance in defs
 
@@ -174,3 +174,216 @@ type ObjectEntry struct {
 	BlocksProjectile           bool                   `json:"blocksProjectile"`
 	RandomizeAnimStart         bool                   `json:"randomizeAnimStart"`
 }
+
+type EnumEntry struct {
+	ID            int      `json:"id"`
+	KeyType       string   `json:"keyType"`
+	ValType       string   `json:"valType"`
+	DefaultString string   `json:"defaultString"`
+	DefaultInt    int      `json:"defaultInt"`
+	Size          int      `json:"size"`
+	Keys          []int    `json:"keys"`
+	IntVals       []int    `json:"intVals"`
+	StringVals    []string `json:"stringVals"`
+}
+
+type StructEntry struct {
+	ID     int                    `json:"id"`
+	Params map[string]interface{} `json:"params"`
+}
+
+type ParamDefEntry struct {
+	ID            int    `json:"id"`
+	Type          string `json:"type"`
+	IsMembers     bool   `json:"isMembers"`
+	DefaultInt    int    `json:"defaultInt"`
+	DefaultString string `json:"defaultString"`
+}
+
+type DBTableEntry struct {
+	ID                  int             `json:"id"`
+	ColumnNames         []string        `json:"columnNames"`
+	Types               [][]string      `json:"types"`
+	DefaultColumnValues [][]interface{} `json:"defaultColumnValues"`
+}
+
+type DBRowEntry struct {
+	ID           int             `json:"id"`
+	TableID      int             `json:"tableId"`
+	ColumnTypes  [][]string      `json:"columnTypes"`
+	ColumnValues [][]interface{} `json:"columnValues"`
+}
+
+type VarbitEntry struct {
+	ID                  int `json:"id"`
+	Index               int `json:"index"`
+	LeastSignificantBit int `json:"leastSignificantBit"`
+	MostSignificantBit  int `json:"mostSignificantBit"`
+}
+
+type SequenceEntry struct {
+	ID                  int                    `json:"id"`
+	FrameIDs            []int                  `json:"frameIDs"`
+	ChatFrameIDs        []int                  `json:"chatFrameIds"`
+	FrameLengths        []int                  `json:"frameLenghts"`
+	FrameStep           int                    `json:"frameStep"`
+	InterleaveLeft      []int                  `json:"interleaveLeft"`
+	Stretches           bool                   `json:"stretches"`
+	ForcedPriority      int                    `json:"forcedPriority"`
+	LeftHandItem        int                    `json:"leftHandItem"`
+	RightHandItem       int                    `json:"rightHandItem"`
+	MaxLoops            int                    `json:"maxLoops"`
+	PrecedenceAnimating int                    `json:"precedenceAnimating"`
+	Priority            int                    `json:"priority"`
+	ReplyMode           int                    `json:"replyMode"`
+	AnimMayaID          int                    `json:"animMayaID"`
+	AnimMayaStart       int                    `json:"animMayaStart"`
+	AnimMayaEnd         int                    `json:"animMayaEnd"`
+	Sounds              map[string]interface{} `json:"sounds"`
+}
+
+type SpotAnimEntry struct {
+	ID               int   `json:"id"`
+	ModelID          int   `json:"modelId"`
+	AnimationID      int   `json:"animationId"`
+	ResizeX          int   `json:"resizeX"`
+	ResizeY          int   `json:"resizeY"`
+	Rotation         int   `json:"rotaton"`
+	Ambient          int   `json:"ambient"`
+	Contrast         int   `json:"contrast"`
+	RecolorToFind    []int `json:"recolorToFind"`
+	RecolorToReplace []int `json:"recolorToReplace"`
+	TextureToFind    []int `json:"textureToFind"`
+	TextureToReplace []int `json:"textureToReplace"`
+}
+
+type KitEntry struct {
+	ID                 int   `json:"id"`
+	BodyPartID         int   `json:"bodyPartId"`
+	Models             []int `json:"models"`
+	ChatheadModels     []int `json:"chatheadModels"`
+	RecolorToFind      []int `json:"recolorToFind"`
+	RecolorToReplace   []int `json:"recolorToReplace"`
+	RetextureToFind    []int `json:"retextureToFind"`
+	RetextureToReplace []int `json:"retextureToReplace"`
+	NonSelectable      bool  `json:"nonSelectable"`
+}
+
+type InventoryEntry struct {
+	ID          int   `json:"id"`
+	Size        int   `json:"size"`
+	StockItems  []int `json:"stockItems"`
+	StockCounts []int `json:"stockCounts"`
+}
+
+type UnderlayEntry struct {
+	ID    int `json:"id"`
+	Color int `json:"color"`
+}
+
+type OverlayEntry struct {
+	ID                int  `json:"id"`
+	RGBColor          int  `json:"rgbColor"`
+	Texture           int  `json:"texture"`
+	SecondaryRGBColor int  `json:"secondaryRgbColor"`
+	HideUnderlay      bool `json:"hideUnderlay"`
+}
+
+type HitsplatEntry struct {
+	ID               int    `json:"id"`
+	StringFormat     string `json:"stringFormat"`
+	FontType         int    `json:"fontType"`
+	TextColor        int    `json:"textColor"`
+	TextOffsetY      int    `json:"textOffsetY"`
+	LeftSprite       int    `json:"leftSprite"`
+	LeftSprite2      int    `json:"leftSprite2"`
+	RightSpriteID    int    `json:"rightSpriteId"`
+	BackgroundSprite int    `json:"backgroundSprite"`
+	UseDamage        int    `json:"useDamage"`
+	DisplayCycles    int    `json:"displayCycles"`
+	FadeStartCycle   int    `json:"fadeStartCycle"`
+	ScrollToOffsetX  int    `json:"scrollToOffsetX"`
+	ScrollToOffsetY  int    `json:"scrollToOffsetY"`
+	VarbitID         int    `json:"varbitID"`
+	VarpID           int    `json:"varpID"`
+	MultiHitsplats   []int  `json:"multihitsplats"`
+}
+
+type HealthBarEntry struct {
+	ID                     int `json:"id"`
+	HealthBarFrontSpriteID int `json:"healthBarFrontSpriteId"`
+	HealthBarBackSpriteID  int `json:"healthBarBackSpriteId"`
+	HealthScale            int `json:"healthScale"`
+	HealthBarPadding       int `json:"healthBarPadding"`
+	Field3276              int `json:"field3276"`
+	Field3277              int `json:"field3277"`
+	Field3278              int `json:"field3278"`
+	Field3283              int `json:"field3283"`
+	Field3272              int `json:"field3272"`
+	Field3275              int `json:"field3275"`
+}
+
+type AreaEntry struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	SpriteID  int      `json:"spriteId"`
+	Field3296 int      `json:"field3296"`
+	Field3298 []string `json:"field3298"`
+	Category  int      `json:"category"`
+}
+
+type InterfaceEntry struct {
+	ID             int      `json:"id"`
+	IsIf3          bool     `json:"isIf3"`
+	Type           int      `json:"type"`
+	ContentType    int      `json:"contentType"`
+	ParentID       int      `json:"parentId"`
+	OriginalX      int      `json:"originalX"`
+	OriginalY      int      `json:"originalY"`
+	OriginalWidth  int      `json:"originalWidth"`
+	OriginalHeight int      `json:"originalHeight"`
+	IsHidden       bool     `json:"isHidden"`
+	SpriteID       int      `json:"spriteId"`
+	ModelType      int      `json:"modelType"`
+	ModelID        int      `json:"modelId"`
+	FontID         int      `json:"fontId"`
+	Text           string   `json:"text"`
+	TextColor      int      `json:"textColor"`
+	Name           string   `json:"name"`
+	Actions        []string `json:"actions"`
+	ItemIDs        []int    `json:"itemIds"`
+	// Every non-null field ending in Listener, e.g. onLoadListener, keyed by field name
+	Listeners map[string]interface{} `json:"-"`
+}
+
+type ScriptEntry struct {
+	ID               int              `json:"id"`
+	IntStackCount    int              `json:"intStackCount"`
+	StringStackCount int              `json:"stringStackCount"`
+	LocalIntCount    int              `json:"localIntCount"`
+	LocalStringCount int              `json:"localStringCount"`
+	Instructions     []int            `json:"instructions"`
+	IntOperands      []int            `json:"intOperands"`
+	StringOperands   []*string        `json:"stringOperands"`
+	Switches         []map[string]int `json:"switches"`
+}
+
+type SpriteEntry struct {
+	ID        int   `json:"id"`
+	Frame     int   `json:"frame"`
+	OffsetX   int   `json:"offsetX"`
+	OffsetY   int   `json:"offsetY"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	MaxWidth  int   `json:"maxWidth"`
+	MaxHeight int   `json:"maxHeight"`
+	Pixels    []int `json:"pixels"`
+}
+
+type TextureEntry struct {
+	ID                 int   `json:"id"`
+	FileIDs            []int `json:"fileIds"`
+	AverageRGB         int   `json:"averageRGB"`
+	AnimationDirection int   `json:"animationDirection"`
+	AnimationSpeed     int   `json:"animationSpeed"`
+}
@@ -0,0 +1,42 @@
+/* Enums.go
+2024, cdfisher
+----------------
+Loads enum definitions into the enums and enum_entries tables. Each enum maps int keys to either
+int or string values depending on its value type.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertEnumData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "enum_defs", func(def EnumEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO enums (id, key_type, val_type, default_string, default_int, size) VALUES (?, ?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.KeyType, def.ValType, def.DefaultString, def.DefaultInt,
+			def.Size)
+		if err != nil {
+			fmt.Printf("Error inserting enum %s : %s\n", fileName, err)
+			return
+		}
+
+		entryStatement := "INSERT OR REPLACE INTO enum_entries (enum_id, key, int_value, string_value) VALUES (?, ?, ?, ?)"
+		for i, key := range def.Keys {
+			var intVal, stringVal any
+			if i < len(def.IntVals) {
+				intVal = def.IntVals[i]
+			}
+			if i < len(def.StringVals) {
+				stringVal = def.StringVals[i]
+			}
+			err = batch.exec(entryStatement, def.ID, key, intVal, stringVal)
+			if err != nil {
+				fmt.Printf("Error inserting entry %d of enum %s : %s\n", key, fileName, err)
+			}
+		}
+	})
+	batch.commit()
+}
@@ -0,0 +1,163 @@
+/* Equipment.go
+2024, cdfisher
+----------------
+Decodes equipment bonuses and wield requirements from item params into the
+equipment_stats table.
+
+Param ids are the ones read by the client's equipment stats interface.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Item param ids holding equipment bonuses
+const (
+	ParamStabAttack     = 0
+	ParamSlashAttack    = 1
+	ParamCrushAttack    = 2
+	ParamMagicAttack    = 3
+	ParamRangedAttack   = 4
+	ParamStabDefence    = 5
+	ParamSlashDefence   = 6
+	ParamCrushDefence   = 7
+	ParamMagicDefence   = 8
+	ParamRangedDefence  = 9
+	ParamPrayer         = 11
+	ParamAttackSpeed    = 14
+	ParamRangedStrength = 189
+	ParamMagicDamage    = 299
+	ParamMeleeStrength  = 641
+)
+
+// Item param id pairs of (skill, level) for wield requirements
+var RequirementParams = [][2]int{
+	{434, 436},
+	{435, 437},
+	{191, 613},
+}
+
+// Skill names indexed by the client's stat id
+var SkillNames = []string{
+	"attack", "defence", "strength", "hitpoints", "ranged", "prayer", "magic", "cooking", "woodcutting",
+	"fletching", "fishing", "firemaking", "crafting", "smithing", "mining", "herblore", "agility", "thieving",
+	"slayer", "farming", "runecraft", "hunter", "construction",
+}
+
+type EquipmentRequirement struct {
+	Skill string `json:"skill"`
+	Level int    `json:"level"`
+}
+
+type EquipmentStats struct {
+	ItemID         int
+	EquipSlot      int
+	StabAttack     int
+	SlashAttack    int
+	CrushAttack    int
+	MagicAttack    int
+	RangedAttack   int
+	StabDefence    int
+	SlashDefence   int
+	CrushDefence   int
+	MagicDefence   int
+	RangedDefence  int
+	MeleeStrength  int
+	RangedStrength int
+	MagicDamage    int
+	Prayer         int
+	AttackSpeed    int
+	Requirements   []EquipmentRequirement
+}
+
+// paramInt reads an integer param from a definition's params map. Params are keyed by
+// their id as a string and unmarshalled as float64.
+func paramInt(params map[string]interface{}, id int) (int, bool) {
+	val, ok := params[strconv.Itoa(id)]
+	if !ok {
+		return 0, false
+	}
+	num, ok := val.(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(num), true
+}
+
+func skillName(id int) string {
+	if id < 0 || id >= len(SkillNames) {
+		return fmt.Sprintf("skill_%d", id)
+	}
+	return SkillNames[id]
+}
+
+// decodeEquipmentStats returns the equipment stats for an item, or false if the item cannot be equipped
+func decodeEquipmentStats(def ItemEntry) (EquipmentStats, bool) {
+	if def.WearPos1 < 0 {
+		return EquipmentStats{}, false
+	}
+
+	stats := EquipmentStats{ItemID: def.ID, EquipSlot: def.WearPos1}
+	fields := map[int]*int{
+		ParamStabAttack:     &stats.StabAttack,
+		ParamSlashAttack:    &stats.SlashAttack,
+		ParamCrushAttack:    &stats.CrushAttack,
+		ParamMagicAttack:    &stats.MagicAttack,
+		ParamRangedAttack:   &stats.RangedAttack,
+		ParamStabDefence:    &stats.StabDefence,
+		ParamSlashDefence:   &stats.SlashDefence,
+		ParamCrushDefence:   &stats.CrushDefence,
+		ParamMagicDefence:   &stats.MagicDefence,
+		ParamRangedDefence:  &stats.RangedDefence,
+		ParamPrayer:         &stats.Prayer,
+		ParamAttackSpeed:    &stats.AttackSpeed,
+		ParamRangedStrength: &stats.RangedStrength,
+		ParamMagicDamage:    &stats.MagicDamage,
+		ParamMeleeStrength:  &stats.MeleeStrength,
+	}
+	for id, field := range fields {
+		if val, ok := paramInt(def.Params, id); ok {
+			*field = val
+		}
+	}
+
+	stats.Requirements = make([]EquipmentRequirement, 0, len(RequirementParams))
+	for _, pair := range RequirementParams {
+		skill, ok := paramInt(def.Params, pair[0])
+		if !ok {
+			continue
+		}
+		level, ok := paramInt(def.Params, pair[1])
+		if !ok {
+			continue
+		}
+		stats.Requirements = append(stats.Requirements, EquipmentRequirement{Skill: skillName(skill), Level: level})
+	}
+
+	return stats, true
+}
+
+func insertEquipmentStats(def ItemEntry, batch *dbBatch) {
+	stats, ok := decodeEquipmentStats(def)
+	if !ok {
+		return
+	}
+
+	requirements, err := json.Marshal(stats.Requirements)
+	if err != nil {
+		fmt.Printf("Error marshalling requirements for item %d : %s\n", def.ID, err)
+	}
+
+	statement := "INSERT OR REPLACE INTO equipment_stats (item_id, equip_slot, stab_attack, slash_attack, crush_attack, magic_attack, ranged_attack, stab_defence, slash_defence, crush_defence, magic_defence, ranged_defence, melee_strength, ranged_strength, magic_damage, prayer, attack_speed, requirements) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	err = batch.exec(statement, stats.ItemID, stats.EquipSlot, stats.StabAttack, stats.SlashAttack,
+		stats.CrushAttack, stats.MagicAttack, stats.RangedAttack, stats.StabDefence, stats.SlashDefence,
+		stats.CrushDefence, stats.MagicDefence, stats.RangedDefence, stats.MeleeStrength, stats.RangedStrength,
+		stats.MagicDamage, stats.Prayer, stats.AttackSpeed, string(requirements))
+	if err != nil {
+		fmt.Printf("Error inserting equipment stats for item %d : %s\n", def.ID, err)
+	}
+}
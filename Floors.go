@@ -0,0 +1,36 @@
+/* Floors.go
+2024, cdfisher
+----------------
+Loads floor underlay and overlay definitions into the underlays and overlays tables. Their
+colours are plain 24-bit RGB rather than the packed HSL used for recolours.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertUnderlayData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "underlay_defs", func(def UnderlayEntry, fileName string) {
+		err := batch.exec("INSERT OR REPLACE INTO underlays (id, color) VALUES (?, ?)", def.ID, def.Color)
+		if err != nil {
+			fmt.Printf("Error inserting underlay %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
+
+func insertOverlayData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "overlay_defs", func(def OverlayEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO overlays (id, color, texture, secondary_color, hide_underlay) VALUES (?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.RGBColor, def.Texture, def.SecondaryRGBColor, def.HideUnderlay)
+		if err != nil {
+			fmt.Printf("Error inserting overlay %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
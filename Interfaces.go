@@ -0,0 +1,55 @@
+/* Interfaces.go
+2024, cdfisher
+----------------
+Loads interface (widget) component definitions into the interfaces table. Component ids pack the
+interface group in their high 16 bits and the component's index in the low 16 bits.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// UnmarshalJSON collects the many listener fields into Listeners alongside the regular fields
+func (def *InterfaceEntry) UnmarshalJSON(data []byte) error {
+	type fields InterfaceEntry
+	if err := json.Unmarshal(data, (*fields)(def)); err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	def.Listeners = make(map[string]interface{})
+	for key, val := range raw {
+		if strings.HasSuffix(key, "Listener") && val != nil {
+			def.Listeners[key] = val
+		}
+	}
+	return nil
+}
+
+func insertInterfaceData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "interface_defs", func(def InterfaceEntry, fileName string) {
+		listeners, err := json.Marshal(def.Listeners)
+		if err != nil {
+			fmt.Printf("Error marshalling listeners of interface %s : %s\n", fileName, err)
+		}
+
+		statement := "INSERT OR REPLACE INTO interfaces (id, group_id, component_id, is_if3, type, content_type, parent_id, x, y, width, height, is_hidden, sprite_id, model_type, model_id, font_id, text, text_color, name, actions, item_ids, listeners) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		err = batch.exec(statement, def.ID, def.ID>>16, def.ID&0xFFFF, def.IsIf3, def.Type, def.ContentType,
+			def.ParentID, def.OriginalX, def.OriginalY, def.OriginalWidth, def.OriginalHeight, def.IsHidden, def.SpriteID,
+			def.ModelType, def.ModelID, def.FontID, def.Text, def.TextColor, def.Name, SliceTextStr(def.Actions),
+			SliceTextInt(def.ItemIDs), string(listeners))
+		if err != nil {
+			fmt.Printf("Error inserting interface %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
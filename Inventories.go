@@ -0,0 +1,39 @@
+/* Inventories.go
+2024, cdfisher
+----------------
+Loads inventory definitions (banks, shops, and other containers) into the inventories table, with
+one row per default stock item in the inventory_stock table.
+
+Stock is only present in dumps that decode it; inventories without stock just have a size.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertInventoryData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "inv_defs", func(def InventoryEntry, fileName string) {
+		err := batch.exec("INSERT OR REPLACE INTO inventories (id, size) VALUES (?, ?)", def.ID, def.Size)
+		if err != nil {
+			fmt.Printf("Error inserting inventory %s : %s\n", fileName, err)
+			return
+		}
+
+		statement := "INSERT OR REPLACE INTO inventory_stock (inventory_id, slot, item_id, count) VALUES (?, ?, ?, ?)"
+		for slot, itemID := range def.StockItems {
+			count := 0
+			if slot < len(def.StockCounts) {
+				count = def.StockCounts[slot]
+			}
+			err = batch.exec(statement, def.ID, slot, itemID, count)
+			if err != nil {
+				fmt.Printf("Error inserting stock slot %d of inventory %s : %s\n", slot, fileName, err)
+			}
+		}
+	})
+	batch.commit()
+}
@@ -0,0 +1,27 @@
+/* Kits.go
+2024, cdfisher
+----------------
+Loads identity kit definitions, the body parts making up a player's default appearance, into the
+kits table.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertKitData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "kit_defs", func(def KitEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO kits (id, body_part_id, models, chathead_models, recolor_to_find, recolor_to_replace, retexture_to_find, retexture_to_replace, non_selectable) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.BodyPartID, SliceTextInt(def.Models),
+			SliceTextInt(def.ChatheadModels), SliceTextInt(def.RecolorToFind), SliceTextInt(def.RecolorToReplace),
+			SliceTextInt(def.RetextureToFind), SliceTextInt(def.RetextureToReplace), def.NonSelectable)
+		if err != nil {
+			fmt.Printf("Error inserting kit %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
@@ -0,0 +1,68 @@
+/* Params.go
+2024, cdfisher
+----------------
+Loads param and struct definitions, and normalizes the params maps of items, NPCs, objects, and
+structs into the params table so they can be queried by param id.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// insertParams stores each entry of a params map as a row in the params table. Params hold either an int or a string.
+func insertParams(batch *dbBatch, entityType string, entityID int, params map[string]interface{}) {
+	statement := "INSERT OR REPLACE INTO params (entity_type, entity_id, param_id, int_value, string_value) VALUES (?, ?, ?, ?, ?)"
+	for key, val := range params {
+		paramID, err := strconv.Atoi(key)
+		if err != nil {
+			fmt.Printf("Invalid param id %s on %s %d\n", key, entityType, entityID)
+			continue
+		}
+
+		var intVal, stringVal any
+		switch v := val.(type) {
+		case float64:
+			intVal = int(v)
+		case string:
+			stringVal = v
+		default:
+			fmt.Printf("Unknown value type for param %s on %s %d : %v\n", key, entityType, entityID, val)
+			continue
+		}
+
+		err = batch.exec(statement, entityType, entityID, paramID, intVal, stringVal)
+		if err != nil {
+			fmt.Printf("Error inserting param %s on %s %d : %s\n", key, entityType, entityID, err)
+		}
+	}
+}
+
+func insertParamDefData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "param_defs", func(def ParamDefEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO param_defs (id, type, is_members, default_int, default_string) VALUES (?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.Type, def.IsMembers, def.DefaultInt, def.DefaultString)
+		if err != nil {
+			fmt.Printf("Error inserting param def %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
+
+func insertStructData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "struct_defs", func(def StructEntry, fileName string) {
+		err := batch.exec("INSERT OR REPLACE INTO structs (id, params) VALUES (?, ?)", def.ID,
+			MapToStr(def.Params))
+		if err != nil {
+			fmt.Printf("Error inserting struct %s : %s\n", fileName, err)
+			return
+		}
+		insertParams(batch, "struct", def.ID, def.Params)
+	})
+	batch.commit()
+}
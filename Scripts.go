@@ -0,0 +1,215 @@
+/* Scripts.go
+2024, cdfisher
+----------------
+Loads ClientScript (CS2) definitions into the scripts table along with a disassembly, and indexes
+the vars, scripts, enums, constants, and opcodes each script references into script_references.
+
+Opcodes without a known name are disassembled as op_<opcode>. Branch and switch targets are
+relative to the following instruction and are shown as absolute instruction indexes.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	OpIconst       = 0
+	OpGetVarp      = 1
+	OpSetVarp      = 2
+	OpSconst       = 3
+	OpJump         = 6
+	OpIfIcmpne     = 7
+	OpIfIcmpeq     = 8
+	OpIfIcmplt     = 9
+	OpIfIcmpgt     = 10
+	OpGetVarbit    = 25
+	OpSetVarbit    = 27
+	OpIfIcmple     = 31
+	OpIfIcmpge     = 32
+	OpInvoke       = 40
+	OpGetVarcInt   = 42
+	OpSetVarcInt   = 43
+	OpGetVarcStr   = 49
+	OpSetVarcStr   = 50
+	OpSwitch       = 60
+	OpEnum         = 3408
+	OpEnumGetCount = 3411
+)
+
+var ScriptOpcodeNames = map[int]string{
+	OpIconst: "iconst", OpGetVarp: "get_varp", OpSetVarp: "set_varp", OpSconst: "sconst", OpJump: "jump",
+	OpIfIcmpne: "if_icmpne", OpIfIcmpeq: "if_icmpeq", OpIfIcmplt: "if_icmplt", OpIfIcmpgt: "if_icmpgt", 21: "return",
+	OpGetVarbit: "get_varbit", OpSetVarbit: "set_varbit", OpIfIcmple: "if_icmple", OpIfIcmpge: "if_icmpge",
+	33: "iload", 34: "istore", 35: "sload", 36: "sstore", 37: "join_string", 38: "pop_int", 39: "pop_string",
+	OpInvoke: "invoke", OpGetVarcInt: "get_varc_int", OpSetVarcInt: "set_varc_int", 44: "define_array",
+	45: "get_array_int", 46: "set_array_int", 47: "get_varc_string_old", 48: "set_varc_string_old",
+	OpGetVarcStr: "get_varc_string", OpSetVarcStr: "set_varc_string", OpSwitch: "switch",
+	100: "cc_create", 101: "cc_delete", 102: "cc_deleteall", 200: "cc_find", 201: "if_find",
+	1000: "cc_setposition", 1001: "cc_setsize", 1003: "cc_sethide", 1100: "cc_setscrollpos", 1101: "cc_setcolour",
+	1102: "cc_setfill", 1103: "cc_settrans", 1104: "cc_setlinewid", 1105: "cc_setgraphic", 1108: "cc_setmodel",
+	1112: "cc_settext", 1113: "cc_settextfont", 1200: "cc_setobject", 1300: "cc_setop",
+	3100: "mes", 3101: "anim", 3103: "if_close", 3300: "clientclock", 3301: "inv_getobj", 3302: "inv_getnum",
+	3303: "inv_total", 3304: "inv_size", 3305: "stat", 3306: "stat_base", 3307: "stat_xp", 3308: "coord",
+	OpEnum: "enum", OpEnumGetCount: "enum_getoutputcount",
+	4000: "add", 4001: "sub", 4002: "multiply", 4003: "div", 4004: "random", 4005: "randominc", 4011: "mod",
+	4014: "and", 4015: "or", 4100: "append_num", 4101: "append", 4106: "tostring", 4107: "compare",
+	4117: "string_length", 4118: "substring", 4200: "oc_name", 4201: "oc_op", 4202: "oc_iop", 4203: "oc_cost",
+	4204: "oc_stackable", 4205: "oc_cert", 4206: "oc_uncert", 4207: "oc_members",
+}
+
+// Reference types recorded for the operand of each opcode
+var ScriptOperandRefTypes = map[int]string{
+	OpIconst:     "constant",
+	OpGetVarp:    "varp",
+	OpSetVarp:    "varp",
+	OpGetVarbit:  "varbit",
+	OpSetVarbit:  "varbit",
+	OpInvoke:     "script",
+	OpGetVarcInt: "varc_int",
+	OpSetVarcInt: "varc_int",
+	OpGetVarcStr: "varc_string",
+	OpSetVarcStr: "varc_string",
+}
+
+var ScriptBranchOpcodes = map[int]bool{OpJump: true, OpIfIcmpne: true, OpIfIcmpeq: true, OpIfIcmplt: true,
+	OpIfIcmpgt: true, OpIfIcmple: true, OpIfIcmpge: true}
+
+// Core opcodes (below 100) which take no operand. Other core opcodes always show theirs, even if 0.
+var ScriptNoOperandOpcodes = map[int]bool{21: true, 38: true, 39: true}
+
+// ScriptReference is a single var, script, enum, constant, or opcode used by a script
+type ScriptReference struct {
+	Type             string
+	ID               int
+	InstructionIndex int
+}
+
+func opcodeName(opcode int) string {
+	if name, ok := ScriptOpcodeNames[opcode]; ok {
+		return name
+	}
+	return fmt.Sprintf("op_%d", opcode)
+}
+
+// operand returns the int operand of an instruction, or 0 if the dump has none for it
+func (def ScriptEntry) operand(i int) int {
+	if i < len(def.IntOperands) {
+		return def.IntOperands[i]
+	}
+	return 0
+}
+
+// disassembleScript renders a script's instructions one per line, followed by its switch tables
+func disassembleScript(def ScriptEntry) string {
+	var lines []string
+	lines = append(lines, fmt.Sprintf("; script %d: %d int args, %d string args, %d int locals, %d string locals",
+		def.ID, def.IntStackCount, def.StringStackCount, def.LocalIntCount, def.LocalStringCount))
+
+	for i, opcode := range def.Instructions {
+		line := fmt.Sprintf("%5d: %s", i, opcodeName(opcode))
+		switch {
+		case opcode == OpSconst:
+			text := ""
+			if i < len(def.StringOperands) && def.StringOperands[i] != nil {
+				text = *def.StringOperands[i]
+			}
+			line += " " + strconv.Quote(text)
+		case ScriptBranchOpcodes[opcode]:
+			line += fmt.Sprintf(" -> %d", i+def.operand(i)+1)
+		case opcode == OpSwitch:
+			line += fmt.Sprintf(" table %d", def.operand(i))
+		case opcode < 100 && !ScriptNoOperandOpcodes[opcode], def.operand(i) != 0:
+			line += fmt.Sprintf(" %d", def.operand(i))
+		}
+		lines = append(lines, line)
+	}
+
+	// Switch targets are relative to the switch instruction, so find which instruction uses each table
+	tableUsers := make(map[int]int)
+	for i, opcode := range def.Instructions {
+		if opcode == OpSwitch {
+			tableUsers[def.operand(i)] = i
+		}
+	}
+	for table, cases := range def.Switches {
+		lines = append(lines, fmt.Sprintf("; switch table %d", table))
+		keys := make([]int, 0, len(cases))
+		for key := range cases {
+			if k, err := strconv.Atoi(key); err == nil {
+				keys = append(keys, k)
+			}
+		}
+		sort.Ints(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf(";   case %d -> %d", key,
+				tableUsers[table]+cases[strconv.Itoa(key)]+1))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findScriptReferences collects the operands of var, invoke, and constant instructions, and the opcodes used, at every
+// instruction using them. Enum ids aren't operands of the enum instruction, so finding them is best-effort: they're
+// taken from an iconst two instructions before it (the enum id is pushed just before the key), or one before
+// enum_getoutputcount. Enums whose id is computed, or whose key takes more than one instruction to push, are missed.
+func findScriptReferences(def ScriptEntry) []ScriptReference {
+	var refs []ScriptReference
+	for i, opcode := range def.Instructions {
+		refs = append(refs, ScriptReference{"opcode", opcode, i})
+		if refType, ok := ScriptOperandRefTypes[opcode]; ok {
+			refs = append(refs, ScriptReference{refType, def.operand(i), i})
+		}
+		if opcode == OpEnum || opcode == OpEnumGetCount {
+			enumIndex := i - 2
+			if opcode == OpEnumGetCount {
+				// enum_getoutputcount only takes the enum id
+				enumIndex = i - 1
+			}
+			if enumIndex >= 0 && def.Instructions[enumIndex] == OpIconst {
+				refs = append(refs, ScriptReference{"enum", def.operand(enumIndex), enumIndex})
+			}
+		}
+	}
+	return refs
+}
+
+func insertScriptData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	for opcode, name := range ScriptOpcodeNames {
+		err := batch.exec("INSERT OR REPLACE INTO script_opcodes (opcode, name) VALUES (?, ?)", opcode, name)
+		if err != nil {
+			fmt.Printf("Error inserting script opcode %d : %s\n", opcode, err)
+		}
+	}
+
+	readDefFiles(cachePath, "script_defs", func(def ScriptEntry, fileName string) {
+		switches, err := json.Marshal(def.Switches)
+		if err != nil {
+			fmt.Printf("Error marshalling switches of script %s : %s\n", fileName, err)
+		}
+
+		statement := "INSERT OR REPLACE INTO scripts (id, int_arg_count, string_arg_count, local_int_count, local_string_count, instruction_count, switches, disassembly) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+		err = batch.exec(statement, def.ID, def.IntStackCount, def.StringStackCount, def.LocalIntCount,
+			def.LocalStringCount, len(def.Instructions), string(switches), disassembleScript(def))
+		if err != nil {
+			fmt.Printf("Error inserting script %s : %s\n", fileName, err)
+			return
+		}
+
+		refStatement := "INSERT OR REPLACE INTO script_references (script_id, ref_type, ref_id, instruction_index) VALUES (?, ?, ?, ?)"
+		for _, ref := range findScriptReferences(def) {
+			err = batch.exec(refStatement, def.ID, ref.Type, ref.ID, ref.InstructionIndex)
+			if err != nil {
+				fmt.Printf("Error inserting %s reference of script %s : %s\n", ref.Type, fileName, err)
+			}
+		}
+	})
+	batch.commit()
+}
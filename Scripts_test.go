@@ -0,0 +1,89 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDisassembleScript(t *testing.T) {
+	text := "hi"
+	tests := []struct {
+		name string
+		def  ScriptEntry
+		want []string
+	}{
+		{"branches, strings, and switches", ScriptEntry{ID: 42, IntStackCount: 1, LocalIntCount: 2,
+			Instructions:   []int{OpIconst, OpGetVarbit, OpIfIcmpeq, OpSconst, OpSwitch, 21},
+			IntOperands:    []int{5, 4567, 1, 0, 0, 0},
+			StringOperands: []*string{nil, nil, nil, &text, nil, nil},
+			Switches:       []map[string]int{{"3": 1, "1": 0}}},
+			[]string{
+				"; script 42: 1 int args, 0 string args, 2 int locals, 0 string locals",
+				"    0: iconst 5",
+				"    1: get_varbit 4567",
+				"    2: if_icmpeq -> 4",
+				`    3: sconst "hi"`,
+				"    4: switch table 0",
+				"    5: return",
+				"; switch table 0",
+				";   case 1 -> 5",
+				";   case 3 -> 6",
+			}},
+		{"operands shown for core opcodes even if 0", ScriptEntry{ID: 1,
+			Instructions: []int{OpIconst, 38, 3100, 9999, 9999},
+			IntOperands:  []int{0, 0, 0, 0, 7}},
+			[]string{
+				"; script 1: 0 int args, 0 string args, 0 int locals, 0 string locals",
+				"    0: iconst 0",
+				"    1: pop_int",
+				"    2: mes",
+				"    3: op_9999",
+				"    4: op_9999 7",
+			}},
+		{"missing operands", ScriptEntry{ID: 2, Instructions: []int{OpGetVarp, OpSconst}},
+			[]string{
+				"; script 2: 0 int args, 0 string args, 0 int locals, 0 string locals",
+				"    0: get_varp 0",
+				`    1: sconst ""`,
+			}},
+	}
+	for _, test := range tests {
+		if got := disassembleScript(test.def); got != strings.Join(test.want, "\n") {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, got, strings.Join(test.want, "\n"))
+		}
+	}
+}
+
+func TestFindScriptReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		def  ScriptEntry
+		want []ScriptReference
+	}{
+		{"every site of a var", ScriptEntry{Instructions: []int{OpGetVarbit, OpGetVarbit},
+			IntOperands: []int{4567, 4567}},
+			[]ScriptReference{{"opcode", OpGetVarbit, 0}, {"varbit", 4567, 0}, {"opcode", OpGetVarbit, 1},
+				{"varbit", 4567, 1}}},
+		{"enum id pushed before its key", ScriptEntry{Instructions: []int{OpIconst, OpIconst, OpEnum},
+			IntOperands: []int{1234, 5, 0}},
+			[]ScriptReference{{"opcode", OpIconst, 0}, {"constant", 1234, 0}, {"opcode", OpIconst, 1},
+				{"constant", 5, 1}, {"opcode", OpEnum, 2}, {"enum", 1234, 0}}},
+		{"enum output count", ScriptEntry{Instructions: []int{OpIconst, OpEnumGetCount}, IntOperands: []int{99, 0}},
+			[]ScriptReference{{"opcode", OpIconst, 0}, {"constant", 99, 0}, {"opcode", OpEnumGetCount, 1},
+				{"enum", 99, 0}}},
+		{"computed enum id is missed", ScriptEntry{Instructions: []int{33, OpIconst, OpEnum}, IntOperands: []int{0, 5, 0}},
+			[]ScriptReference{{"opcode", 33, 0}, {"opcode", OpIconst, 1}, {"constant", 5, 1}, {"opcode", OpEnum, 2}}},
+		{"enum at the start of a script", ScriptEntry{Instructions: []int{OpEnum}},
+			[]ScriptReference{{"opcode", OpEnum, 0}}},
+		{"invoke", ScriptEntry{Instructions: []int{OpInvoke}, IntOperands: []int{500}},
+			[]ScriptReference{{"opcode", OpInvoke, 0}, {"script", 500, 0}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := findScriptReferences(test.def); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
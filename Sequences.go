@@ -0,0 +1,58 @@
+/* Sequences.go
+2024, cdfisher
+----------------
+Loads sequence (animation) definitions into the sequences and sequence_frames tables.
+
+Frame lengths are in client ticks (20ms). Skeletal (maya) animations have no frames and instead
+play from animMayaStart to animMayaEnd, one client tick per frame.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// sequenceDuration returns how long one play of a sequence lasts in client ticks
+func sequenceDuration(def SequenceEntry) int {
+	if def.AnimMayaID != -1 && len(def.FrameLengths) == 0 {
+		return def.AnimMayaEnd - def.AnimMayaStart
+	}
+	duration := 0
+	for _, length := range def.FrameLengths {
+		duration += length
+	}
+	return duration
+}
+
+func insertSequenceData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "sequence_defs", func(def SequenceEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO sequences (id, frame_count, frame_step, interleave_left, stretches, forced_priority, left_hand_item, right_hand_item, max_loops, precedence_animating, priority, reply_mode, anim_maya_id, anim_maya_start, anim_maya_end, sounds, duration_client_ticks) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, len(def.FrameIDs), def.FrameStep, SliceTextInt(def.InterleaveLeft),
+			def.Stretches, def.ForcedPriority, def.LeftHandItem, def.RightHandItem, def.MaxLoops,
+			def.PrecedenceAnimating, def.Priority, def.ReplyMode, def.AnimMayaID, def.AnimMayaStart, def.AnimMayaEnd,
+			MapToStr(def.Sounds), sequenceDuration(def))
+		if err != nil {
+			fmt.Printf("Error inserting sequence %s : %s\n", fileName, err)
+			return
+		}
+
+		frameStatement := "INSERT OR REPLACE INTO sequence_frames (sequence_id, frame_index, frame_id, frame_length, chat_frame_id) VALUES (?, ?, ?, ?, ?)"
+		for i, frameID := range def.FrameIDs {
+			length, chatFrameID := 0, -1
+			if i < len(def.FrameLengths) {
+				length = def.FrameLengths[i]
+			}
+			if i < len(def.ChatFrameIDs) {
+				chatFrameID = def.ChatFrameIDs[i]
+			}
+			err = batch.exec(frameStatement, def.ID, i, frameID, length, chatFrameID)
+			if err != nil {
+				fmt.Printf("Error inserting frame %d of sequence %s : %s\n", i, fileName, err)
+			}
+		}
+	})
+	batch.commit()
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestSequenceDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		def  SequenceEntry
+		want int
+	}{
+		{"frame lengths", SequenceEntry{AnimMayaID: -1, FrameLengths: []int{4, 4, 5}}, 13},
+		{"no frames", SequenceEntry{AnimMayaID: -1}, 0},
+		{"maya animation", SequenceEntry{AnimMayaID: 12, AnimMayaStart: 10, AnimMayaEnd: 70}, 60},
+		{"maya animation with frame lengths", SequenceEntry{AnimMayaID: 12, AnimMayaStart: 10, AnimMayaEnd: 70,
+			FrameLengths: []int{3, 3}}, 6},
+	}
+	for _, test := range tests {
+		if got := sequenceDuration(test.def); got != test.want {
+			t.Errorf("%s: sequenceDuration() = %d, want %d", test.name, got, test.want)
+		}
+	}
+}
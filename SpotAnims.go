@@ -0,0 +1,32 @@
+/* SpotAnims.go
+2024, cdfisher
+----------------
+Loads spot animation (graphics) definitions, used for projectiles and spell effects, into the
+spotanims table.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertSpotAnimData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "spotanim_defs", func(def SpotAnimEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO spotanims (id, model_id, animation_id, resize_x, resize_y, rotation, ambient, contrast, recolor_to_find, recolor_to_replace, texture_to_find, texture_to_replace) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.ModelID, def.AnimationID, def.ResizeX, def.ResizeY,
+			def.Rotation, def.Ambient, def.Contrast, SliceTextInt(def.RecolorToFind), SliceTextInt(def.RecolorToReplace),
+			SliceTextInt(def.TextureToFind), SliceTextInt(def.TextureToReplace))
+		if err != nil {
+			fmt.Printf("Error inserting spotanim %s : %s\n", fileName, err)
+			return
+		}
+
+		indexSpotAnimModels(def, batch)
+		indexSpotAnimAnimations(def, batch)
+		indexSpotAnimTextures(def, batch)
+	})
+	batch.commit()
+}
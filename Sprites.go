@@ -0,0 +1,63 @@
+/* Sprites.go
+2024, cdfisher
+----------------
+Loads sprite groups into the sprites table, one row per frame. Each sprite_defs file holds the
+array of frames in a group.
+
+Pixels are stored as a blob of 4-byte big-endian ARGB values, row by row. Pixels of 0 are
+transparent. Dumps without an alpha channel leave the alpha byte of every pixel as 0, so if no pixel
+in a group has any alpha, its other pixels are stored fully opaque.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+)
+
+// spriteGroupHasAlpha reports whether any pixel in a group sets its alpha byte
+func spriteGroupHasAlpha(frames []SpriteEntry) bool {
+	for _, def := range frames {
+		for _, pixel := range def.Pixels {
+			if uint32(pixel)>>24 != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func spritePixelBlob(pixels []int, hasAlpha bool) []byte {
+	blob := make([]byte, 4*len(pixels))
+	for i, pixel := range pixels {
+		argb := uint32(pixel)
+		if !hasAlpha && argb != 0 {
+			argb |= 0xFF000000
+		}
+		binary.BigEndian.PutUint32(blob[4*i:], argb)
+	}
+	return blob
+}
+
+func insertSpriteData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "sprite_defs", func(frames []SpriteEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO sprites (sprite_id, frame, offset_x, offset_y, width, height, max_width, max_height, pixels) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		hasAlpha := spriteGroupHasAlpha(frames)
+		for _, def := range frames {
+			if len(def.Pixels) != def.Width*def.Height {
+				fmt.Printf("Skipping frame %d of sprite %s : %d pixels for a %dx%d frame\n", def.Frame, fileName,
+					len(def.Pixels), def.Width, def.Height)
+				continue
+			}
+			err := batch.exec(statement, def.ID, def.Frame, def.OffsetX, def.OffsetY, def.Width, def.Height,
+				def.MaxWidth, def.MaxHeight, spritePixelBlob(def.Pixels, hasAlpha))
+			if err != nil {
+				fmt.Printf("Error inserting frame %d of sprite %s : %s\n", def.Frame, fileName, err)
+			}
+		}
+	})
+	batch.commit()
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSpritePixelBlob(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []SpriteEntry
+		want   []byte
+	}{
+		{"no alpha channel is made opaque", []SpriteEntry{{Pixels: []int{0xFF0000, 0}}},
+			[]byte{0xFF, 0xFF, 0, 0, 0, 0, 0, 0}},
+		{"alpha channel is kept", []SpriteEntry{{Pixels: []int{0x80FF0000, 0x00FF0000}}},
+			[]byte{0x80, 0xFF, 0, 0, 0, 0xFF, 0, 0}},
+		{"alpha in another frame of the group", []SpriteEntry{{Pixels: []int{0x00FF0000}}, {Pixels: []int{-1}}},
+			[]byte{0, 0xFF, 0, 0}},
+	}
+	for _, test := range tests {
+		got := spritePixelBlob(test.frames[0].Pixels, spriteGroupHasAlpha(test.frames))
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("%s: got % X, want % X", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+/* Textures.go
+2024, cdfisher
+----------------
+Loads texture definitions into the textures table. A texture's fileIds are the sprites it is
+drawn from.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func insertTextureData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "texture_defs", func(def TextureEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO textures (id, sprite_ids, average_rgb, animation_direction, animation_speed) VALUES (?, ?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, SliceTextInt(def.FileIDs), def.AverageRGB, def.AnimationDirection,
+			def.AnimationSpeed)
+		if err != nil {
+			fmt.Printf("Error inserting texture %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
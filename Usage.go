@@ -0,0 +1,108 @@
+/* Usage.go
+2024, cdfisher
+----------------
+Builds reverse indexes of which entities use a given model, animation, etc.
+Each index table holds rows of (<resource>_id, entity_type, entity_id, role) where role is the
+field the resource was found in. Textures instead use the roles find and replace for every entity
+type, since each type names its retexture fields differently.
+*/
+
+package main
+
+import "fmt"
+
+// insertUsage records each resource id an entity uses in the given role, skipping unused (-1) slots
+func insertUsage(batch *dbBatch, table string, entityType string, entityID int, role string, ids ...int) {
+	statement := fmt.Sprintf("INSERT OR REPLACE INTO %s VALUES (?, ?, ?, ?)", table)
+	for _, id := range ids {
+		if id < 0 {
+			continue
+		}
+		err := batch.exec(statement, id, entityType, entityID, role)
+		if err != nil {
+			fmt.Printf("Error inserting %s row for %s %d : %s\n", table, entityType, entityID, err)
+		}
+	}
+}
+
+func indexItemModels(def ItemEntry, batch *dbBatch) {
+	roles := map[string]int{
+		"inventory_model":     def.InventoryModel,
+		"male_model_0":        def.MaleModel0,
+		"male_model_1":        def.MaleModel1,
+		"male_model_2":        def.MaleModel2,
+		"male_head_model":     def.MaleHeadModel,
+		"male_head_model_2":   def.MaleHeadModel2,
+		"female_model_0":      def.FemaleModel0,
+		"female_model_1":      def.FemaleModel1,
+		"female_model_2":      def.FemaleModel2,
+		"female_head_model":   def.FemaleHeadModel,
+		"female_head_model_2": def.FemaleHeadModel2,
+	}
+	for role, id := range roles {
+		insertUsage(batch, "model_usage", "item", def.ID, role, id)
+	}
+}
+
+func indexNPCModels(def NPCEntry, batch *dbBatch) {
+	insertUsage(batch, "model_usage", "npc", def.ID, "models", def.Models...)
+	insertUsage(batch, "model_usage", "npc", def.ID, "chathead_models", def.ChatheadModels...)
+}
+
+func indexObjectModels(def ObjectEntry, batch *dbBatch) {
+	insertUsage(batch, "model_usage", "object", def.ID, "object_models", def.ObjectModels...)
+}
+
+func indexSpotAnimModels(def SpotAnimEntry, batch *dbBatch) {
+	insertUsage(batch, "model_usage", "spotanim", def.ID, "model_id", def.ModelID)
+}
+
+func indexNPCAnimations(def NPCEntry, batch *dbBatch) {
+	roles := map[string]int{
+		"standing_animation":           def.StandingAnimation,
+		"idle_rotate_left_animation":   def.IdleRotateLeftAnimation,
+		"idle_rotate_right_animation":  def.IdleRotateRightAnimation,
+		"walking_animation":            def.WalkingAnimation,
+		"rotate_left_animation":        def.RotateLeftAnimation,
+		"rotate_right_animation":       def.RotateRightAnimation,
+		"run_animation":                def.RunAnimation,
+		"run_rotate_180_animation":     def.RunRotate180Animation,
+		"run_rotate_left_animation":    def.RunRotateLeftAnimation,
+		"run_rotate_right_animation":   def.RunRotateRightAnimation,
+		"crawl_animation":              def.CrawlAnimation,
+		"crawl_rotate_180_animation":   def.CrawlRotate180Animation,
+		"crawl_rotate_left_animation":  def.CrawlRotateLeftAnimation,
+		"crawl_rotate_right_animation": def.CrawlRotateRightAnimation,
+	}
+	for role, id := range roles {
+		insertUsage(batch, "animation_usage", "npc", def.ID, role, id)
+	}
+}
+
+func indexObjectAnimations(def ObjectEntry, batch *dbBatch) {
+	insertUsage(batch, "animation_usage", "object", def.ID, "animation_id", def.AnimationID)
+}
+
+func indexSpotAnimAnimations(def SpotAnimEntry, batch *dbBatch) {
+	insertUsage(batch, "animation_usage", "spotanim", def.ID, "animation_id", def.AnimationID)
+}
+
+func indexItemTextures(def ItemEntry, batch *dbBatch) {
+	insertUsage(batch, "texture_usage", "item", def.ID, "find", def.TextureFind...)
+	insertUsage(batch, "texture_usage", "item", def.ID, "replace", def.TextureReplace...)
+}
+
+func indexNPCTextures(def NPCEntry, batch *dbBatch) {
+	insertUsage(batch, "texture_usage", "npc", def.ID, "find", def.RetextureToFind...)
+	insertUsage(batch, "texture_usage", "npc", def.ID, "replace", def.RetextureToReplace...)
+}
+
+func indexObjectTextures(def ObjectEntry, batch *dbBatch) {
+	insertUsage(batch, "texture_usage", "object", def.ID, "find", def.RetextureToFind...)
+	insertUsage(batch, "texture_usage", "object", def.ID, "replace", def.TextureToReplace...)
+}
+
+func indexSpotAnimTextures(def SpotAnimEntry, batch *dbBatch) {
+	insertUsage(batch, "texture_usage", "spotanim", def.ID, "find", def.TextureToFind...)
+	insertUsage(batch, "texture_usage", "spotanim", def.ID, "replace", def.TextureToReplace...)
+}
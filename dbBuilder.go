@@ -4,7 +4,11 @@
 Loads OSRS cache dumps either retrieved from github.com/abextm/osrs-cache
 or dumped using the dumper from github.com/abextm/osrs-flatcache into a SQLite3 DB.
 
-Currently, this supports loading from the item_defs, npc_defs, and object_defs directories.
+Currently, this supports loading from the item_defs, npc_defs, object_defs, enum_defs,
+param_defs, struct_defs, dbtable_defs, dbrow_defs,
+varbit_defs, sequence_defs, spotanim_defs, kit_defs, inv_defs,
+underlay_defs, overlay_defs, hitsplat_defs, healthbar_defs,
+area_defs, interface_defs, script_defs, sprite_defs, and texture_defs directories.
 
 Table creations and insertions are based on keys found in Abex's dump of cache 221.7
 (2024-05-15-rev221)
@@ -16,7 +20,7 @@ dbBuilder TODOs:
 ----------------
 - Take cache version/path as CL args so this can just be provided as an executable
 - Add handling for when new keys are included in definition entries: Add a table/tables for new keys
-- Add support for other objects in cache: dbtables, param_defs, ???
+- Add support for other objects in cache: ???
 - Maybe reorder columns to match appearance in defs/group them a bit more sensibly
 
 */
@@ -78,6 +82,25 @@ func getFileNames(dir string) []string {
 	return names
 }
 
+// readDefFiles reads and unmarshals every definition file in a dump directory, passing each to insert
+func readDefFiles[T any](cachePath string, dir string, insert func(def T, fileName string)) {
+	defFiles := getFileNames(fmt.Sprintf("%s\\%s", cachePath, dir))
+
+	for i := range defFiles {
+		fileBytes, err := os.ReadFile(fmt.Sprintf("%s\\%s\\%s", cachePath, dir, defFiles[i]))
+		if err != nil {
+			fmt.Printf("Error reading %s file %s to bytes: %s\n", dir, defFiles[i], err)
+			continue
+		}
+		var def T
+		if err = json.Unmarshal(fileBytes, &def); err != nil {
+			fmt.Printf("Error unmarshalling %s file %s : %s\n", dir, defFiles[i], err)
+			continue
+		}
+		insert(def, defFiles[i])
+	}
+}
+
 func initializeDB(dbfile string) *sql.DB {
 	// load schema.sql into string
 	creationStatement, err := os.ReadFile("schema.sql")
@@ -101,9 +124,48 @@ func initializeDB(dbfile string) *sql.DB {
 	return database
 }
 
+// dbBatch runs a loader's inserts in a single transaction, preparing each distinct statement once
+type dbBatch struct {
+	tx         *sql.Tx
+	statements map[string]*sql.Stmt
+}
+
+func beginBatch(database *sql.DB) *dbBatch {
+	tx, err := database.Begin()
+	if err != nil {
+		log.Fatal("Could not begin transaction: ", err)
+	}
+	return &dbBatch{tx: tx, statements: make(map[string]*sql.Stmt)}
+}
+
+// exec runs a statement in the batch's transaction, preparing it the first time it is used
+func (b *dbBatch) exec(statement string, args ...any) error {
+	preparedStatement, ok := b.statements[statement]
+	if !ok {
+		var err error
+		preparedStatement, err = b.tx.Prepare(statement)
+		if err != nil {
+			return err
+		}
+		b.statements[statement] = preparedStatement
+	}
+	_, err := preparedStatement.Exec(args...)
+	return err
+}
+
+func (b *dbBatch) commit() {
+	for _, preparedStatement := range b.statements {
+		preparedStatement.Close()
+	}
+	if err := b.tx.Commit(); err != nil {
+		log.Fatal("Could not commit transaction: ", err)
+	}
+}
+
 func insertItemData(cachePath string, database *sql.DB) {
 	// get all files in /item_defs
 	itemFiles := getFileNames(fmt.Sprintf("%s\\item_defs", cachePath))
+	batch := beginBatch(database)
 
 	// for each file loop through
 	for i := range itemFiles {
@@ -120,15 +182,12 @@ func insertItemData(cachePath string, database *sql.DB) {
 		}
 
 		// SQL time
-		statement := "INSERT OR REPLACE INTO items (id, name, examine, resize_x, resize_y, resize_z, xan2d, yan2d, zan2d, cost, is_tradable, stackable, inventory_model, wear_pos_1, wear_pos_2, wear_pos_3, members, zoom_2d, x_offset_2d, y_offset_2d, ambient, contrast, options, interface_options, male_model_0, male_model_1, male_model_2, male_offset, male_head_model, male_head_model_2, female_model_0, female_model_1, female_model_2, female_offset, female_head_model, female_head_model_2, noted_id, noted_template, team, weight, shift_click_drop_index, bought_id, bought_template_id, placeholder_id, placeholder_template_id, color_find, color_replace, params, count_co, count_obj, texture_find, texture_replace, category) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
-		preparedStatement, err := database.Prepare(statement)
-		if err != nil {
-			fmt.Printf("Error preparing item insertion statement for item %s : %s\n", itemFiles[i], err)
-			log.Fatal(err)
-		}
+		statement := "INSERT OR REPLACE INTO items (id, name, examine, resize_x, resize_y, resize_z, xan2d, yan2d, zan2d, cost, is_tradable, stackable, inventory_model, wear_pos_1, wear_pos_2, wear_pos_3, members, zoom_2d, x_offset_2d, y_offset_2d, ambient, contrast, options, interface_options, male_model_0, male_model_1, male_model_2, male_offset, male_head_model, male_head_model_2, female_model_0, female_model_1, female_model_2, female_offset, female_head_model, female_head_model_2, noted_id, noted_template, team, weight, shift_click_drop_index, bought_id, bought_template_id, placeholder_id, placeholder_template_id, color_find, color_replace, params, count_co, count_obj, texture_find, texture_replace, category, variant, base_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+		variant, baseID := itemVariant(def)
 
 		// I don't love this
-		_, err = preparedStatement.Exec(def.ID, def.Name, def.Examine, def.ResizeX, def.ResizeY, def.ResizeZ, def.Xan2D,
+		err = batch.exec(statement, def.ID, def.Name, def.Examine, def.ResizeX, def.ResizeY, def.ResizeZ, def.Xan2D,
 			def.Yan2D, def.Zan2D, def.Cost, def.IsTradable, def.Stackable, def.InventoryModel, def.WearPos1,
 			def.WearPos2, def.WearPos3, def.Members, def.Zoom2D, def.XOffset2D, def.YOffset2D, def.Ambient,
 			def.Contrast, SliceTextStr(def.Options), SliceTextStr(def.InterfaceOptions), def.MaleModel0, def.MaleModel1,
@@ -137,18 +196,49 @@ func insertItemData(cachePath string, database *sql.DB) {
 			def.NotedTemplate, def.Team, def.Weight, def.ShiftClickDropIndex, def.BoughtID, def.BoughtTemplateID,
 			def.PlaceholderID, def.PlaceholderTemplateID, SliceTextInt(def.ColorFind), SliceTextInt(def.ColorReplace),
 			MapToStr(def.Params), SliceTextInt(def.CountCo), SliceTextInt(def.CountObj), SliceTextInt(def.TextureFind),
-			SliceTextInt(def.TextureReplace), def.Category)
+			SliceTextInt(def.TextureReplace), def.Category, variant, baseID)
 
 		if err != nil {
 			fmt.Printf("Error executing prepared statement for item %s : %s\n", itemFiles[i], err)
 		}
+
+		insertEquipmentStats(def, batch)
+		indexItemModels(def, batch)
+		indexItemTextures(def, batch)
+		insertParams(batch, "item", def.ID, def.Params)
 	}
+	batch.commit()
+}
 
+// itemVariant returns whether an item is a base item or a noted, placeholder, or bought copy of one,
+// along with the id of the base item (-1 for base items)
+func itemVariant(def ItemEntry) (string, int) {
+	switch {
+	case def.NotedTemplate != -1:
+		return "noted", def.NotedID
+	case def.PlaceholderTemplateID != -1:
+		return "placeholder", def.PlaceholderID
+	case def.BoughtTemplateID != -1:
+		return "bought", def.BoughtID
+	default:
+		return "base", -1
+	}
+}
+
+// resolveItemVariants copies the fields that noted, placeholder, and bought items inherit from their base item
+// in the client, since the dump leaves them empty or set to the template's values
+func resolveItemVariants(database *sql.DB) {
+	statement := "UPDATE items SET name = base.name, members = base.members, cost = base.cost FROM items AS base WHERE items.base_id = base.id AND items.variant != 'base'"
+	_, err := database.Exec(statement)
+	if err != nil {
+		fmt.Printf("Error resolving item variants : %s\n", err)
+	}
 }
 
 func insertNPCData(cachePath string, database *sql.DB) {
 	// get all files in /npc_defs
 	npcFiles := getFileNames(fmt.Sprintf("%s\\npc_defs", cachePath))
+	batch := beginBatch(database)
 
 	// for each file loop through
 	for i := range npcFiles {
@@ -166,14 +256,9 @@ func insertNPCData(cachePath string, database *sql.DB) {
 
 		// SQL time
 		statement := "INSERT OR REPLACE INTO npcs (id, name, size, models, chathead_models, standing_animation,  idle_rotate_left_animation, idle_rotate_right_animation, walking_animation, rotate_left_animation, rotate_right_animation, run_animation, run_rotate_180_animation, run_rotate_left_animation, run_rotate_right_animation, crawl_animation, crawl_rotate_180_animation, crawl_rotate_left_animation, crawl_rotate_right_animation, actions, is_minimap_visible, combat_level, width_scale, height_scale, has_render_priority, ambient, contrast, head_icon_sprite_index, head_icon_archive_ids, rotation_speed, varbit_id, varp_index, is_interactable, rotation_flag, is_pet, configs, params, category, recolor_to_find, recolor_to_replace, retexture_to_find, retexture_to_replace, is_follower, low_priority_follower_ops) VALUES (?, ?, ?, ?, ?, ?,  ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
-		preparedStatement, err := database.Prepare(statement)
-		if err != nil {
-			fmt.Printf("Error preparing NPC insertion statement for item %s : %s\n", npcFiles[i], err)
-			log.Fatal(err)
-		}
 
 		// I don't love this
-		_, err = preparedStatement.Exec(def.ID, def.Name, def.Size, SliceTextInt(def.Models),
+		err = batch.exec(statement, def.ID, def.Name, def.Size, SliceTextInt(def.Models),
 			SliceTextInt(def.ChatheadModels), def.StandingAnimation, def.IdleRotateLeftAnimation,
 			def.IdleRotateRightAnimation, def.WalkingAnimation, def.RotateLeftAnimation, def.RotateRightAnimation,
 			def.RunAnimation, def.RunRotate180Animation, def.RunRotateLeftAnimation, def.RunRotateRightAnimation,
@@ -189,12 +274,19 @@ func insertNPCData(cachePath string, database *sql.DB) {
 		if err != nil {
 			fmt.Printf("Error executing prepared statement for npc %s : %s\n", npcFiles[i], err)
 		}
+
+		indexNPCModels(def, batch)
+		indexNPCTextures(def, batch)
+		indexNPCAnimations(def, batch)
+		insertParams(batch, "npc", def.ID, def.Params)
 	}
+	batch.commit()
 }
 
 func insertObjectData(cachePath string, database *sql.DB) {
 	// get all files in /object_defs
 	objectFiles := getFileNames(fmt.Sprintf("%s\\object_defs", cachePath))
+	batch := beginBatch(database)
 
 	// for each file loop through
 	for i := range objectFiles {
@@ -212,14 +304,9 @@ func insertObjectData(cachePath string, database *sql.DB) {
 
 		// SQL time
 		statement := "INSERT OR REPLACE INTO objects (id, name, decor_displacement, is_hollow, object_models, object_types, map_area_id, size_x, size_y, offset_x, offset_y, offset_height, merge_normals, wall_or_door, animation_id, varbit_id, ambient, contrast, recolor_to_find,  recolor_to_replace, retexture_to_find, texture_to_replace, actions, interact_type, map_scene_id, blocking_mask, shadow, model_size_x, model_size_y, model_size_height, object_id, obstructs_ground, contoured_ground, supports_items, config_change_dest, category, is_rotated, varp_id, ambient_sound_id, ambient_sound_ids, ambient_sound_retain, ambient_sound_distance, ambient_sound_change_ticks_min, ambient_sound_change_ticks_max, params, a_bool_2111, blocks_projectile, randomize_anim_start) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,  ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
-		preparedStatement, err := database.Prepare(statement)
-		if err != nil {
-			fmt.Printf("Error preparing object insertion statement for item %s : %s\n", objectFiles[i], err)
-			log.Fatal(err)
-		}
 
 		// I don't love this
-		_, err = preparedStatement.Exec(def.ID, def.Name, def.DecorDisplacement, def.IsHollow,
+		err = batch.exec(statement, def.ID, def.Name, def.DecorDisplacement, def.IsHollow,
 			SliceTextInt(def.ObjectModels), SliceTextInt(def.ObjectTypes), def.MapAreaID, def.SizeX, def.SizeY,
 			def.OffsetX, def.OffsetY, def.OffsetHeight, def.MergeNormals, def.WallOrDoor, def.AnimationID, def.VarbitID,
 			def.Ambient, def.Contrast, SliceTextInt(def.RecolorToFind), SliceTextInt(def.RecolorToReplace),
@@ -234,16 +321,89 @@ func insertObjectData(cachePath string, database *sql.DB) {
 		if err != nil {
 			fmt.Printf("Error executing prepared statement for object %s : %s\n", objectFiles[i], err)
 		}
+
+		indexObjectModels(def, batch)
+		indexObjectTextures(def, batch)
+		indexObjectAnimations(def, batch)
+		insertParams(batch, "object", def.ID, def.Params)
+	}
+	batch.commit()
+}
+
+func insertVarbitData(cachePath string, database *sql.DB) {
+	batch := beginBatch(database)
+	readDefFiles(cachePath, "varbit_defs", func(def VarbitEntry, fileName string) {
+		statement := "INSERT OR REPLACE INTO varbits (id, base_var, least_significant_bit, most_significant_bit) VALUES (?, ?, ?, ?)"
+		err := batch.exec(statement, def.ID, def.Index, def.LeastSignificantBit, def.MostSignificantBit)
+		if err != nil {
+			fmt.Printf("Error inserting varbit %s : %s\n", fileName, err)
+		}
+	})
+	batch.commit()
+}
+
+// indexVarDependents records which NPCs and objects change appearance with each varbit and varp
+func indexVarDependents(database *sql.DB) {
+	statements := []string{
+		"INSERT OR REPLACE INTO var_dependents SELECT 'varbit', varbit_id, 'npc', id FROM npcs WHERE varbit_id != -1",
+		"INSERT OR REPLACE INTO var_dependents SELECT 'varp', varp_index, 'npc', id FROM npcs WHERE varp_index != -1",
+		"INSERT OR REPLACE INTO var_dependents SELECT 'varbit', varbit_id, 'object', id FROM objects WHERE varbit_id != -1",
+		"INSERT OR REPLACE INTO var_dependents SELECT 'varp', varp_id, 'object', id FROM objects WHERE varp_id != -1",
+	}
+	for _, statement := range statements {
+		_, err := database.Exec(statement)
+		if err != nil {
+			fmt.Printf("Error indexing var dependents : %s\n", err)
+		}
 	}
 }
 
 func PopulateTables(cachePath string, database *sql.DB) {
 	fmt.Printf("Inserting items at %s\n", time.Now().Format(time.DateTime))
 	insertItemData(cachePath, database)
+	resolveItemVariants(database)
 	fmt.Printf("Inserting NPCs at %s\n", time.Now().Format(time.DateTime))
 	insertNPCData(cachePath, database)
 	fmt.Printf("Inserting objects at %s\n", time.Now().Format(time.DateTime))
 	insertObjectData(cachePath, database)
+	fmt.Printf("Inserting enums at %s\n", time.Now().Format(time.DateTime))
+	insertEnumData(cachePath, database)
+	fmt.Printf("Inserting param defs at %s\n", time.Now().Format(time.DateTime))
+	insertParamDefData(cachePath, database)
+	fmt.Printf("Inserting structs at %s\n", time.Now().Format(time.DateTime))
+	insertStructData(cachePath, database)
+	fmt.Printf("Inserting dbtables at %s\n", time.Now().Format(time.DateTime))
+	insertDBTableData(cachePath, database)
+	fmt.Printf("Inserting dbrows at %s\n", time.Now().Format(time.DateTime))
+	insertDBRowData(cachePath, database)
+	fmt.Printf("Inserting varbits at %s\n", time.Now().Format(time.DateTime))
+	insertVarbitData(cachePath, database)
+	fmt.Printf("Inserting sequences at %s\n", time.Now().Format(time.DateTime))
+	insertSequenceData(cachePath, database)
+	fmt.Printf("Inserting spotanims at %s\n", time.Now().Format(time.DateTime))
+	insertSpotAnimData(cachePath, database)
+	fmt.Printf("Inserting kits at %s\n", time.Now().Format(time.DateTime))
+	insertKitData(cachePath, database)
+	fmt.Printf("Inserting inventories at %s\n", time.Now().Format(time.DateTime))
+	insertInventoryData(cachePath, database)
+	fmt.Printf("Inserting underlays and overlays at %s\n", time.Now().Format(time.DateTime))
+	insertUnderlayData(cachePath, database)
+	insertOverlayData(cachePath, database)
+	fmt.Printf("Inserting hitsplats and healthbars at %s\n", time.Now().Format(time.DateTime))
+	insertHitsplatData(cachePath, database)
+	insertHealthBarData(cachePath, database)
+	fmt.Printf("Inserting areas at %s\n", time.Now().Format(time.DateTime))
+	insertAreaData(cachePath, database)
+	fmt.Printf("Inserting interfaces at %s\n", time.Now().Format(time.DateTime))
+	insertInterfaceData(cachePath, database)
+	fmt.Printf("Inserting scripts at %s\n", time.Now().Format(time.DateTime))
+	insertScriptData(cachePath, database)
+	fmt.Printf("Inserting sprites at %s\n", time.Now().Format(time.DateTime))
+	insertSpriteData(cachePath, database)
+	fmt.Printf("Inserting textures at %s\n", time.Now().Format(time.DateTime))
+	insertTextureData(cachePath, database)
+	fmt.Printf("Indexing var dependents at %s\n", time.Now().Format(time.DateTime))
+	indexVarDependents(database)
 	fmt.Printf("Finished at %s\n", time.Now().Format(time.DateTime))
 }
 
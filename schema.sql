@@ -51,7 +51,9 @@ CREATE TABLE IF NOT EXISTS items (
 	count_obj TEXT COLLATE NOCASE,
 	texture_find TEXT COLLATE NOCASE,
 	texture_replace TEXT COLLATE NOCASE,
-	category INTEGER
+	category INTEGER,
+	variant TEXT,
+	base_id INTEGER
 );
 
 CREATE TABLE IF NOT EXISTS npcs (
@@ -150,4 +152,341 @@ CREATE TABLE IF NOT EXISTS objects (
 	a_bool_2111 TEXT,
 	blocks_projectile TEXT,
 	randomize_anim_start TEXT
-);
\ No newline at end of file
+);
+CREATE TABLE IF NOT EXISTS equipment_stats (
+	item_id INTEGER PRIMARY KEY,
+	equip_slot INTEGER,
+	stab_attack INTEGER,
+	slash_attack INTEGER,
+	crush_attack INTEGER,
+	magic_attack INTEGER,
+	ranged_attack INTEGER,
+	stab_defence INTEGER,
+	slash_defence INTEGER,
+	crush_defence INTEGER,
+	magic_defence INTEGER,
+	ranged_defence INTEGER,
+	melee_strength INTEGER,
+	ranged_strength INTEGER,
+	magic_damage INTEGER,
+	prayer INTEGER,
+	attack_speed INTEGER,
+	requirements TEXT COLLATE NOCASE
+);
+
+CREATE TABLE IF NOT EXISTS var_dependents (
+	var_type TEXT,
+	var_id INTEGER,
+	entity_type TEXT,
+	entity_id INTEGER,
+	PRIMARY KEY (var_type, var_id, entity_type, entity_id)
+);
+
+CREATE TABLE IF NOT EXISTS model_usage (
+	model_id INTEGER,
+	entity_type TEXT,
+	entity_id INTEGER,
+	role TEXT,
+	PRIMARY KEY (model_id, entity_type, entity_id, role)
+);
+
+CREATE TABLE IF NOT EXISTS animation_usage (
+	animation_id INTEGER,
+	entity_type TEXT,
+	entity_id INTEGER,
+	role TEXT,
+	PRIMARY KEY (animation_id, entity_type, entity_id, role)
+);
+
+CREATE TABLE IF NOT EXISTS enums (
+	id INTEGER PRIMARY KEY,
+	key_type TEXT,
+	val_type TEXT,
+	default_string TEXT COLLATE NOCASE,
+	default_int INTEGER,
+	size INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS enum_entries (
+	enum_id INTEGER,
+	key INTEGER,
+	int_value INTEGER,
+	string_value TEXT COLLATE NOCASE,
+	PRIMARY KEY (enum_id, key)
+);
+
+CREATE TABLE IF NOT EXISTS param_defs (
+	id INTEGER PRIMARY KEY,
+	type TEXT,
+	is_members TEXT,
+	default_int INTEGER,
+	default_string TEXT COLLATE NOCASE
+);
+
+CREATE TABLE IF NOT EXISTS structs (
+	id INTEGER PRIMARY KEY,
+	params TEXT COLLATE NOCASE
+);
+
+CREATE TABLE IF NOT EXISTS params (
+	entity_type TEXT,
+	entity_id INTEGER,
+	param_id INTEGER,
+	int_value INTEGER,
+	string_value TEXT COLLATE NOCASE,
+	PRIMARY KEY (entity_type, entity_id, param_id)
+);
+
+CREATE TABLE IF NOT EXISTS dbtables (
+	id INTEGER PRIMARY KEY,
+	column_count INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS dbtable_columns (
+	table_id INTEGER,
+	column_id INTEGER,
+	name TEXT COLLATE NOCASE,
+	types TEXT,
+	default_values TEXT COLLATE NOCASE,
+	PRIMARY KEY (table_id, column_id)
+);
+
+CREATE TABLE IF NOT EXISTS dbrows (
+	id INTEGER PRIMARY KEY,
+	table_id INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS dbrow_values (
+	row_id INTEGER,
+	table_id INTEGER,
+	column_id INTEGER,
+	value_index INTEGER,
+	type TEXT,
+	int_value INTEGER,
+	string_value TEXT COLLATE NOCASE,
+	PRIMARY KEY (row_id, column_id, value_index)
+);
+
+CREATE INDEX IF NOT EXISTS dbrow_values_column ON dbrow_values (table_id, column_id);
+
+CREATE TABLE IF NOT EXISTS varbits (
+	id INTEGER PRIMARY KEY,
+	base_var INTEGER,
+	least_significant_bit INTEGER,
+	most_significant_bit INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS varbits_base_var ON varbits (base_var);
+
+CREATE TABLE IF NOT EXISTS sequences (
+	id INTEGER PRIMARY KEY,
+	frame_count INTEGER,
+	frame_step INTEGER,
+	interleave_left TEXT COLLATE NOCASE,
+	stretches TEXT,
+	forced_priority INTEGER,
+	left_hand_item INTEGER,
+	right_hand_item INTEGER,
+	max_loops INTEGER,
+	precedence_animating INTEGER,
+	priority INTEGER,
+	reply_mode INTEGER,
+	anim_maya_id INTEGER,
+	anim_maya_start INTEGER,
+	anim_maya_end INTEGER,
+	sounds TEXT COLLATE NOCASE,
+	duration_client_ticks INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS sequence_frames (
+	sequence_id INTEGER,
+	frame_index INTEGER,
+	frame_id INTEGER,
+	frame_length INTEGER,
+	chat_frame_id INTEGER,
+	PRIMARY KEY (sequence_id, frame_index)
+);
+
+CREATE TABLE IF NOT EXISTS spotanims (
+	id INTEGER PRIMARY KEY,
+	model_id INTEGER,
+	animation_id INTEGER,
+	resize_x INTEGER,
+	resize_y INTEGER,
+	rotation INTEGER,
+	ambient INTEGER,
+	contrast INTEGER,
+	recolor_to_find TEXT COLLATE NOCASE,
+	recolor_to_replace TEXT COLLATE NOCASE,
+	texture_to_find TEXT COLLATE NOCASE,
+	texture_to_replace TEXT COLLATE NOCASE
+);
+
+CREATE TABLE IF NOT EXISTS kits (
+	id INTEGER PRIMARY KEY,
+	body_part_id INTEGER,
+	models TEXT COLLATE NOCASE,
+	chathead_models TEXT COLLATE NOCASE,
+	recolor_to_find TEXT COLLATE NOCASE,
+	recolor_to_replace TEXT COLLATE NOCASE,
+	retexture_to_find TEXT COLLATE NOCASE,
+	retexture_to_replace TEXT COLLATE NOCASE,
+	non_selectable TEXT
+);
+
+CREATE INDEX IF NOT EXISTS kits_body_part_id ON kits (body_part_id);
+
+CREATE TABLE IF NOT EXISTS inventories (
+	id INTEGER PRIMARY KEY,
+	size INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS inventory_stock (
+	inventory_id INTEGER,
+	slot INTEGER,
+	item_id INTEGER,
+	count INTEGER,
+	PRIMARY KEY (inventory_id, slot)
+);
+
+CREATE INDEX IF NOT EXISTS inventory_stock_item_id ON inventory_stock (item_id);
+
+CREATE TABLE IF NOT EXISTS underlays (
+	id INTEGER PRIMARY KEY,
+	color INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS overlays (
+	id INTEGER PRIMARY KEY,
+	color INTEGER,
+	texture INTEGER,
+	secondary_color INTEGER,
+	hide_underlay TEXT
+);
+
+CREATE TABLE IF NOT EXISTS hitsplats (
+	id INTEGER PRIMARY KEY,
+	string_format TEXT COLLATE NOCASE,
+	font_type INTEGER,
+	text_color INTEGER,
+	text_offset_y INTEGER,
+	left_sprite INTEGER,
+	left_sprite_2 INTEGER,
+	right_sprite_id INTEGER,
+	background_sprite INTEGER,
+	use_damage INTEGER,
+	display_cycles INTEGER,
+	fade_start_cycle INTEGER,
+	scroll_to_offset_x INTEGER,
+	scroll_to_offset_y INTEGER,
+	varbit_id INTEGER,
+	varp_id INTEGER,
+	multihitsplats TEXT COLLATE NOCASE
+);
+
+CREATE TABLE IF NOT EXISTS healthbars (
+	id INTEGER PRIMARY KEY,
+	front_sprite_id INTEGER,
+	back_sprite_id INTEGER,
+	health_scale INTEGER,
+	padding INTEGER,
+	field_3276 INTEGER,
+	field_3277 INTEGER,
+	field_3278 INTEGER,
+	field_3283 INTEGER,
+	field_3272 INTEGER,
+	field_3275 INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS areas (
+	id INTEGER PRIMARY KEY,
+	name TEXT COLLATE NOCASE,
+	sprite_id INTEGER,
+	text_color INTEGER,
+	options TEXT COLLATE NOCASE,
+	category INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS objects_map_area_id ON objects (map_area_id);
+
+CREATE TABLE IF NOT EXISTS interfaces (
+	id INTEGER PRIMARY KEY,
+	group_id INTEGER,
+	component_id INTEGER,
+	is_if3 TEXT,
+	type INTEGER,
+	content_type INTEGER,
+	parent_id INTEGER,
+	x INTEGER,
+	y INTEGER,
+	width INTEGER,
+	height INTEGER,
+	is_hidden TEXT,
+	sprite_id INTEGER,
+	model_type INTEGER,
+	model_id INTEGER,
+	font_id INTEGER,
+	text TEXT COLLATE NOCASE,
+	text_color INTEGER,
+	name TEXT COLLATE NOCASE,
+	actions TEXT COLLATE NOCASE,
+	item_ids TEXT COLLATE NOCASE,
+	listeners TEXT COLLATE NOCASE
+);
+
+CREATE INDEX IF NOT EXISTS interfaces_group_id ON interfaces (group_id);
+
+CREATE TABLE IF NOT EXISTS scripts (
+	id INTEGER PRIMARY KEY,
+	int_arg_count INTEGER,
+	string_arg_count INTEGER,
+	local_int_count INTEGER,
+	local_string_count INTEGER,
+	instruction_count INTEGER,
+	switches TEXT,
+	disassembly TEXT
+);
+
+CREATE TABLE IF NOT EXISTS script_references (
+	script_id INTEGER,
+	ref_type TEXT,
+	ref_id INTEGER,
+	instruction_index INTEGER,
+	PRIMARY KEY (script_id, ref_type, ref_id, instruction_index)
+);
+
+CREATE INDEX IF NOT EXISTS script_references_ref ON script_references (ref_type, ref_id);
+
+CREATE TABLE IF NOT EXISTS script_opcodes (
+	opcode INTEGER PRIMARY KEY,
+	name TEXT COLLATE NOCASE
+);
+
+CREATE TABLE IF NOT EXISTS sprites (
+	sprite_id INTEGER,
+	frame INTEGER,
+	offset_x INTEGER,
+	offset_y INTEGER,
+	width INTEGER,
+	height INTEGER,
+	max_width INTEGER,
+	max_height INTEGER,
+	pixels BLOB,
+	PRIMARY KEY (sprite_id, frame)
+);
+
+CREATE TABLE IF NOT EXISTS textures (
+	id INTEGER PRIMARY KEY,
+	sprite_ids TEXT COLLATE NOCASE,
+	average_rgb INTEGER,
+	animation_direction INTEGER,
+	animation_speed INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS texture_usage (
+	texture_id INTEGER,
+	entity_type TEXT,
+	entity_id INTEGER,
+	role TEXT,
+	PRIMARY KEY (texture_id, entity_type, entity_id, role)
+);
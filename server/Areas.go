@@ -0,0 +1,115 @@
+/* Areas.go
+2024, cdfisher
+----------------
+Handlers for area (world map element) definitions and the objects linked to them by
+map_area_id, and annotation of objects with the area they show on the minimap.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var ObjectAnnotations = map[string]bool{"map_area": true}
+
+// parseAnnotate reads the annotate query param, responding with 400 and returning false if it names an unknown
+// annotation
+func parseAnnotate(c *gin.Context, allowed map[string]bool) (map[string]bool, bool) {
+	annotations := make(map[string]bool)
+	annotate := c.Query("annotate")
+	if annotate == "" {
+		return annotations, true
+	}
+
+	for _, annotation := range strings.Split(annotate, ",") {
+		if !allowed[annotation] {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Cannot annotate %s on route %s",
+				annotation, c.FullPath())})
+			return nil, false
+		}
+		annotations[annotation] = true
+	}
+	return annotations, true
+}
+
+func fetchArea(areaID int, c *gin.Context) *AreaDefEntry {
+	area := AreaDefEntry{}
+	var options string
+
+	query := "SELECT id, name, sprite_id, text_color, options, category FROM areas WHERE id == ?"
+	row := db.QueryRowContext(c, query, areaID)
+	err := row.Scan(&area.ID, &area.Name, &area.SpriteID, &area.TextColor, &options, &area.Category)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for area ", areaID, " : ", err)
+	}
+	area.TextColorHex = rgbToHex(area.TextColor)
+	area.Options = json.RawMessage(options)
+	return &area
+}
+
+// annotateObjects adds the icon sprite of each object's map area, and the area's name as a fallback label for areas
+// without one, if the map_area annotation was requested
+func annotateObjects(objects []ObjectEntry, annotations map[string]bool, c *gin.Context) {
+	if !annotations["map_area"] {
+		return
+	}
+	areas := make(map[int]*AreaDefEntry)
+	for i := range objects {
+		areaID := objects[i].MapAreaID
+		if areaID == -1 {
+			continue
+		}
+		area, ok := areas[areaID]
+		if !ok {
+			area = fetchArea(areaID, c)
+			areas[areaID] = area
+		}
+		if area == nil {
+			continue
+		}
+		if area.SpriteID != -1 {
+			spriteID := area.SpriteID
+			objects[i].MapAreaSpriteID = &spriteID
+		}
+		objects[i].MapAreaName = area.Name
+	}
+}
+
+func GetArea(c *gin.Context) {
+	area := fetchArea(idParam(c, "id"), c)
+
+	if area != nil {
+		c.JSON(http.StatusOK, area)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No area with id " + c.Param("id") + " was found"})
+	}
+}
+
+// GetAreaObjects lists every object shown on the minimap with an area
+func GetAreaObjects(c *gin.Context) {
+	annotations, ok := parseAnnotate(c, ObjectAnnotations)
+	if !ok {
+		return
+	}
+
+	areaID := idParam(c, "id")
+	results := fetchObjects(fmt.Sprintf(Queries[1], "objects", "map_area_id"), strconv.Itoa(areaID), c)
+	annotateObjects(results, annotations, c)
+	decodeObjectColors(results, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No objects with area " + c.Param("id") + " were found"})
+	}
+}
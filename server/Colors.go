@@ -0,0 +1,276 @@
+/* Colors.go
+2024, cdfisher
+----------------
+Decoding of the packed 16-bit HSL colours used by recolor fields, and searching for entities
+recoloured to something close to a target colour.
+
+Packed colours hold 6 bits of hue, 3 bits of saturation, and 7 bits of lightness. Decoded colours
+don't have the client's brightness setting applied.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const defaultColorTolerance = 10.0
+
+// jagexHSLToRGB converts a packed HSL colour to a 24-bit RGB colour
+func jagexHSLToRGB(hsl int) int {
+	hue := float64((hsl>>10)&63)/64 + 0.0078125
+	saturation := float64((hsl>>7)&7)/8 + 0.0625
+	lightness := float64(hsl&127) / 128
+
+	r, g, b := lightness, lightness, lightness
+	if saturation != 0 {
+		var q float64
+		if lightness < 0.5 {
+			q = lightness * (1 + saturation)
+		} else {
+			q = lightness + saturation - lightness*saturation
+		}
+		p := 2*lightness - q
+		r = hueToChannel(p, q, hue+1.0/3)
+		g = hueToChannel(p, q, hue)
+		b = hueToChannel(p, q, hue-1.0/3)
+	}
+
+	return int(r*256)<<16 | int(g*256)<<8 | int(b*256)
+}
+
+func hueToChannel(p float64, q float64, t float64) float64 {
+	if t < 0 {
+		t += 1
+	}
+	if t > 1 {
+		t -= 1
+	}
+	switch {
+	case 6*t < 1:
+		return p + (q-p)*6*t
+	case 2*t < 1:
+		return q
+	case 3*t < 2:
+		return p + (q-p)*(2.0/3-t)*6
+	default:
+		return p
+	}
+}
+
+func rgbToHex(rgb int) string {
+	return fmt.Sprintf("#%06x", rgb&0xFFFFFF)
+}
+
+// rgbToHSL converts a 24-bit RGB colour to a hue in degrees and saturation and lightness between 0 and 1
+func rgbToHSL(rgb int) HSLColor {
+	r, g, b := float64(rgb>>16&0xFF)/255, float64(rgb>>8&0xFF)/255, float64(rgb&0xFF)/255
+	maxChannel, minChannel := max(r, g, b), min(r, g, b)
+	delta := maxChannel - minChannel
+
+	hsl := HSLColor{Lightness: (maxChannel + minChannel) / 2}
+	if delta == 0 {
+		return hsl
+	}
+	hsl.Saturation = delta / (1 - math.Abs(2*hsl.Lightness-1))
+	switch maxChannel {
+	case r:
+		hsl.Hue = math.Mod((g-b)/delta+6, 6) * 60
+	case g:
+		hsl.Hue = ((b-r)/delta + 2) * 60
+	default:
+		hsl.Hue = ((r-g)/delta + 4) * 60
+	}
+	return hsl
+}
+
+// parseHexColor reads an RGB colour in the form #rrggbb or rrggbb
+func parseHexColor(hex string) (int, bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return 0, false
+	}
+	rgb, err := strconv.ParseInt(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int(rgb), true
+}
+
+// decodeColors converts a JSON array of packed HSL colours to RGB hex strings
+func decodeColors(text string) []string {
+	var output []string
+	for _, hsl := range parseIntSlice(text) {
+		output = append(output, rgbToHex(jagexHSLToRGB(hsl)))
+	}
+	return output
+}
+
+// rgbToLab converts an sRGB colour to CIELAB under a D65 white point
+func rgbToLab(rgb int) (float64, float64, float64) {
+	linear := func(channel int) float64 {
+		c := float64(channel) / 255
+		if c <= 0.04045 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	r, g, b := linear(rgb>>16&0xFF), linear(rgb>>8&0xFF), linear(rgb&0xFF)
+
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// colorDistance is the CIE76 difference between two RGB colours, where ~2.3 is a just noticeable difference
+func colorDistance(rgb1 int, rgb2 int) float64 {
+	l1, a1, b1 := rgbToLab(rgb1)
+	l2, a2, b2 := rgbToLab(rgb2)
+	return math.Sqrt((l1-l2)*(l1-l2) + (a1-a2)*(a1-a2) + (b1-b2)*(b1-b2))
+}
+
+// anyColorNear reports whether any packed HSL colour in a JSON array is within tolerance of an RGB colour
+func anyColorNear(text string, target int, tolerance float64) bool {
+	for _, hsl := range parseIntSlice(text) {
+		if colorDistance(jagexHSLToRGB(hsl), target) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeItemColors(items []ItemEntry, c *gin.Context) {
+	if c.Query("colors") != "rgb" {
+		return
+	}
+	for i := range items {
+		items[i].ColorFindRGB = decodeColors(items[i].ColorFind)
+		items[i].ColorReplaceRGB = decodeColors(items[i].ColorReplace)
+	}
+}
+
+func decodeNPCColors(npcs []NPCEntry, c *gin.Context) {
+	if c.Query("colors") != "rgb" {
+		return
+	}
+	for i := range npcs {
+		npcs[i].RecolorToFindRGB = decodeColors(npcs[i].RecolorToFind)
+		npcs[i].RecolorToReplaceRGB = decodeColors(npcs[i].RecolorToReplace)
+	}
+}
+
+func decodeObjectColors(objects []ObjectEntry, c *gin.Context) {
+	if c.Query("colors") != "rgb" {
+		return
+	}
+	for i := range objects {
+		objects[i].RecolorToFindRGB = decodeColors(objects[i].RecolorToFind)
+		objects[i].RecolorToReplaceRGB = decodeColors(objects[i].RecolorToReplace)
+	}
+}
+
+// parseRecolorNear reads the near and tolerance query params, responding with 400 and returning false if
+// either is invalid
+func parseRecolorNear(c *gin.Context) (int, float64, bool) {
+	target, ok := parseHexColor(c.Query("near"))
+	if !ok {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "near must be an RGB hex colour like #ff0000"})
+		return 0, 0, false
+	}
+
+	tolerance := defaultColorTolerance
+	if val, ok := c.GetQuery("tolerance"); ok {
+		var err error
+		tolerance, err = strconv.ParseFloat(val, 64)
+		if err != nil || tolerance < 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid tolerance %s", val)})
+			return 0, 0, false
+		}
+	}
+	return target, tolerance, true
+}
+
+// SearchItemColors returns every item with a replacement colour near the near query param
+func SearchItemColors(c *gin.Context) {
+	target, tolerance, ok := parseRecolorNear(c)
+	if !ok {
+		return
+	}
+
+	query, args, ok := itemVariantQuery("SELECT * FROM items WHERE color_replace != ? ORDER BY id", []any{"null"}, c)
+	if !ok {
+		return
+	}
+
+	var results []ItemEntry
+	for _, item := range fetchItems(query, args, c) {
+		if anyColorNear(item.ColorReplace, target, tolerance) {
+			results = append(results, item)
+		}
+	}
+	decodeItemColors(results, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No items matching query were found"})
+	}
+}
+
+// SearchNPCColors returns every NPC with a replacement colour near the near query param
+func SearchNPCColors(c *gin.Context) {
+	target, tolerance, ok := parseRecolorNear(c)
+	if !ok {
+		return
+	}
+
+	var results []NPCEntry
+	for _, npc := range fetchNPCs("SELECT * FROM npcs WHERE recolor_to_replace != ? ORDER BY id", "null", c) {
+		if anyColorNear(npc.RecolorToReplace, target, tolerance) {
+			results = append(results, npc)
+		}
+	}
+	decodeNPCColors(results, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No NPCs matching query were found"})
+	}
+}
+
+// SearchObjectColors returns every object with a replacement colour near the near query param
+func SearchObjectColors(c *gin.Context) {
+	target, tolerance, ok := parseRecolorNear(c)
+	if !ok {
+		return
+	}
+
+	var results []ObjectEntry
+	for _, object := range fetchObjects("SELECT * FROM objects WHERE recolor_to_replace != ? ORDER BY id", "null", c) {
+		if anyColorNear(object.RecolorToReplace, target, tolerance) {
+			results = append(results, object)
+		}
+	}
+	decodeObjectColors(results, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No objects matching query were found"})
+	}
+}
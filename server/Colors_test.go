@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestJagexHSLToRGB(t *testing.T) {
+	tests := []struct {
+		name string
+		hsl  int
+		want int
+	}{
+		{"black", 0, 0x000000},
+		{"near white", 127, 0xFEFDFD},
+		{"saturated red", 0<<10 | 7<<7 | 64, 0xF81308},
+		{"saturated green", 21<<10 | 7<<7 | 64, 0x08F80B},
+		{"saturated blue", 43<<10 | 7<<7 | 64, 0x1A08F8},
+	}
+	for _, test := range tests {
+		if got := jagexHSLToRGB(test.hsl); got != test.want {
+			t.Errorf("%s: jagexHSLToRGB(%d) = %06X, want %06X", test.name, test.hsl, got, test.want)
+		}
+	}
+}
+
+func TestColorDistance(t *testing.T) {
+	if got := colorDistance(0x123456, 0x123456); got != 0 {
+		t.Errorf("identical colours are %.2f apart, want 0", got)
+	}
+	if a, b := colorDistance(0x000000, 0xFF0000), colorDistance(0xFF0000, 0x000000); a != b {
+		t.Errorf("distance is not symmetric: %.2f and %.2f", a, b)
+	}
+
+	// Reference CIE76 distances from black
+	for rgb, want := range map[int]float64{0xFFFFFF: 100, 0xFF0000: 117.34, 0x00FF00: 148.47} {
+		if got := colorDistance(0x000000, rgb); math.Abs(got-want) > 0.05 {
+			t.Errorf("colorDistance(000000, %06X) = %.2f, want %.2f", rgb, got, want)
+		}
+	}
+}
+
+// recolorItem returns a complete items row replacing a single colour, so that every column scans
+func recolorItem(id int, name string, variant string, colorReplace int) string {
+	return fmt.Sprintf("(%d, '%s', '', 128, 128, 128, 0, 0, 0, 1, 'true', 0, 0, -1, -1, -1, 'false', 0, 0, 0, 0, 0, "+
+		"'null', 'null', -1, -1, -1, 0, -1, -1, -1, -1, -1, 0, -1, -1, -1, -1, -1, 0, -1, -1, -1, -1, -1, "+
+		"'null', '[%d]', 'null', 'null', 'null', 'null', 'null', -1, '%s', -1)", id, name, colorReplace, variant)
+}
+
+func TestSearchItemColors(t *testing.T) {
+	red := 0<<10 | 7<<7 | 64
+	green := 21<<10 | 7<<7 | 64
+	openTestDB(t, "INSERT INTO items VALUES "+recolorItem(1007, "Red cape", "base", red)+", "+
+		recolorItem(1008, "Red cape", "noted", red)+", "+recolorItem(1027, "Green cape", "base", green))
+
+	tests := []struct {
+		url    string
+		status int
+		ids    []int
+	}{
+		{"/items/recolor?near=f81308", http.StatusOK, []int{1007, 1008}},
+		{"/items/recolor?near=%23ff0000&tolerance=20", http.StatusOK, []int{1007, 1008}},
+		{"/items/recolor?near=f81308&variant=base", http.StatusOK, []int{1007}},
+		{"/items/recolor?near=08f80b", http.StatusOK, []int{1027}},
+		{"/items/recolor?near=0000ff&tolerance=5", http.StatusNotFound, nil},
+		{"/items/recolor?near=f81308&variant=cert", http.StatusBadRequest, nil},
+		{"/items/recolor?near=red", http.StatusBadRequest, nil},
+		{"/items/recolor?near=f81308&tolerance=-1", http.StatusBadRequest, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			recorder := serve(http.MethodGet, test.url, "")
+			if recorder.Code != test.status {
+				t.Fatalf("got status %d, want %d: %s", recorder.Code, test.status, recorder.Body)
+			}
+			if test.ids == nil {
+				return
+			}
+
+			var items []ItemEntry
+			if err := json.Unmarshal(recorder.Body.Bytes(), &items); err != nil {
+				t.Fatal(err)
+			}
+			var ids []int
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			if !reflect.DeepEqual(ids, test.ids) {
+				t.Errorf("got items %v, want %v", ids, test.ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/* CombatOverlays.go
+2024, cdfisher
+----------------
+Handlers for hitsplat and healthbar definitions, searched by key and value like items.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+func fetchHitsplats(query string, hitsplatID string, c *gin.Context) []HitsplatDefEntry {
+	var output []HitsplatDefEntry
+	dbRows, err := db.QueryContext(c, query, hitsplatID)
+	if err != nil {
+		log.Fatal("Error encountered executing query for search ", hitsplatID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := HitsplatDefEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.StringFormat, &rowData.FontType, &rowData.TextColor,
+			&rowData.TextOffsetY, &rowData.LeftSprite, &rowData.LeftSprite2, &rowData.RightSpriteID,
+			&rowData.BackgroundSprite, &rowData.UseDamage, &rowData.DisplayCycles, &rowData.FadeStartCycle,
+			&rowData.ScrollToOffsetX, &rowData.ScrollToOffsetY, &rowData.VarbitID, &rowData.VarpID,
+			&rowData.MultiHitsplats)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.TextColorHex = rgbToHex(rowData.TextColor)
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func fetchHealthBars(query string, healthBarID string, c *gin.Context) []HealthBarDefEntry {
+	var output []HealthBarDefEntry
+	dbRows, err := db.QueryContext(c, query, healthBarID)
+	if err != nil {
+		log.Fatal("Error encountered executing query for search ", healthBarID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := HealthBarDefEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.FrontSpriteID, &rowData.BackSpriteID, &rowData.HealthScale,
+			&rowData.Padding, &rowData.Field3276, &rowData.Field3277, &rowData.Field3278, &rowData.Field3283,
+			&rowData.Field3272, &rowData.Field3275)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func GetHitsplats(c *gin.Context) {
+	queryString := buildKeyQuery(HitsplatQueryTypes, "hitsplats", c.Param("key"), c)
+	if queryString == "" {
+		return
+	}
+	results := fetchHitsplats(queryString, c.Param("value"), c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No hitsplats matching query were found"})
+	}
+}
+
+func GetHealthBars(c *gin.Context) {
+	queryString := buildKeyQuery(HealthBarQueryTypes, "healthbars", c.Param("key"), c)
+	if queryString == "" {
+		return
+	}
+	results := fetchHealthBars(queryString, c.Param("value"), c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No healthbars matching query were found"})
+	}
+}
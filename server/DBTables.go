@@ -0,0 +1,229 @@
+/* DBTables.go
+2024, cdfisher
+----------------
+Handlers for dbtables and dbrows. Row columns are returned as lists of tuples typed by the
+column's types.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const dbTableQuery = "SELECT t.id, t.column_count, (SELECT COUNT(*) FROM dbrows r WHERE r.table_id = t.id) FROM dbtables t"
+
+func fetchDBTables(query string, args []any, c *gin.Context) []DBTableDefEntry {
+	var output []DBTableDefEntry
+
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing dbtable query ", args, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := DBTableDefEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.ColumnCount, &rowData.RowCount)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+// fetchDBTableColumns returns the columns of a table keyed by column id
+func fetchDBTableColumns(tableID int, c *gin.Context) map[int]DBColumnEntry {
+	output := make(map[int]DBColumnEntry)
+
+	query := "SELECT column_id, COALESCE(name, ''), types, default_values FROM dbtable_columns WHERE table_id == ? ORDER BY column_id"
+	dbRows, err := db.QueryContext(c, query, tableID)
+	if err != nil {
+		log.Fatal("Error encountered executing column query for dbtable ", tableID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := DBColumnEntry{}
+		var types, defaults string
+		err = dbRows.Scan(&rowData.ID, &rowData.Name, &types, &defaults)
+		if err != nil {
+			fmt.Println(err)
+		}
+		if err = json.Unmarshal([]byte(types), &rowData.Types); err != nil {
+			fmt.Printf("Error unmarshalling types of dbtable %d column %d : %s\n", tableID, rowData.ID, err)
+		}
+		rowData.Defaults = json.RawMessage(defaults)
+		output[rowData.ID] = rowData
+	}
+	return output
+}
+
+// fetchDBRow returns a row with its values grouped into typed tuples per column, or nil if no row has the given id.
+// tableColumns may be passed in to avoid looking them up again for every row of a table.
+func fetchDBRow(rowID int, tableColumns map[int]DBColumnEntry, c *gin.Context) *DBRowDefEntry {
+	dbRow := DBRowDefEntry{Columns: []DBRowColumnEntry{}}
+
+	row := db.QueryRowContext(c, "SELECT id, table_id FROM dbrows WHERE id == ?", rowID)
+	err := row.Scan(&dbRow.ID, &dbRow.TableID)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for dbrow ", rowID, " : ", err)
+	}
+	if tableColumns == nil {
+		tableColumns = fetchDBTableColumns(dbRow.TableID, c)
+	}
+
+	query := "SELECT column_id, value_index, type, int_value, string_value FROM dbrow_values WHERE row_id == ? ORDER BY column_id, value_index"
+	dbRows, err := db.QueryContext(c, query, rowID)
+	if err != nil {
+		log.Fatal("Error encountered executing value query for dbrow ", rowID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	var column *DBRowColumnEntry
+	for dbRows.Next() {
+		var columnID, valueIndex int
+		var varType, stringVal sql.NullString
+		var intVal sql.NullInt64
+		err = dbRows.Scan(&columnID, &valueIndex, &varType, &intVal, &stringVal)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		if column == nil || column.ID != columnID {
+			tableColumn := tableColumns[columnID]
+			dbRow.Columns = append(dbRow.Columns, DBRowColumnEntry{ID: columnID, Name: tableColumn.Name,
+				Types: tableColumn.Types, Values: [][]any{}})
+			column = &dbRow.Columns[len(dbRow.Columns)-1]
+		}
+
+		// Fall back to the table's types for rows dumped without their own
+		tupleSize := max(len(column.Types), 1)
+		if !varType.Valid && len(column.Types) > 0 {
+			varType.String = column.Types[valueIndex%tupleSize]
+		}
+		var value any
+		if intVal.Valid || stringVal.Valid {
+			value = typedValue(varType.String, intVal, stringVal)
+		}
+		column.Values = placeTupleValue(column.Values, valueIndex, tupleSize, value)
+	}
+	return &dbRow
+}
+
+// placeTupleValue puts the value at valueIndex of a column's flattened values into its tuple, adding any tuples
+// missing before it so that a gap in the value indexes can't shift later values into the wrong tuple. Slots with no
+// value are left nil.
+func placeTupleValue(tuples [][]any, valueIndex int, tupleSize int, value any) [][]any {
+	for len(tuples) <= valueIndex/tupleSize {
+		tuples = append(tuples, make([]any, tupleSize))
+	}
+	tuples[valueIndex/tupleSize][valueIndex%tupleSize] = value
+	return tuples
+}
+
+func GetDBTables(c *gin.Context) {
+	results := fetchDBTables(dbTableQuery+" ORDER BY t.id", nil, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No dbtables were found"})
+	}
+}
+
+func GetDBTable(c *gin.Context) {
+	tableID := idParam(c, "id")
+
+	results := fetchDBTables(dbTableQuery+" WHERE t.id == ?", []any{tableID}, c)
+	if len(results) == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No dbtable with id " + c.Param("id") + " was found"})
+		return
+	}
+
+	table := results[0]
+	columns := fetchDBTableColumns(tableID, c)
+	table.Columns = make([]DBColumnEntry, 0, len(columns))
+	for column := 0; column < table.ColumnCount; column++ {
+		if info, ok := columns[column]; ok {
+			table.Columns = append(table.Columns, info)
+		}
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+// GetDBTableRows returns every row of a table, filtered by pairs of column and value query params. A row matches a
+// filter if any value in that column equals the given value, e.g. ?column=0&value=Varrock&column=2&value=1
+func GetDBTableRows(c *gin.Context) {
+	tableID := idParam(c, "id")
+
+	filterColumns := c.QueryArray("column")
+	filterValues := c.QueryArray("value")
+	if len(filterColumns) != len(filterValues) {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "Each column filter needs a matching value"})
+		return
+	}
+
+	conditions := []string{"table_id == ?"}
+	args := []any{tableID}
+	for i := range filterColumns {
+		columnID, err := strconv.Atoi(filterColumns[i])
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid column %s",
+				filterColumns[i])})
+			return
+		}
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM dbrow_values v WHERE v.row_id = dbrows.id AND v.column_id == ? AND (v.int_value == ? OR v.string_value == ?))")
+		args = append(args, columnID, filterValues[i], filterValues[i])
+	}
+
+	query := fmt.Sprintf("SELECT id FROM dbrows WHERE %s ORDER BY id", strings.Join(conditions, " AND "))
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing row query for dbtable ", tableID, " : ", err)
+	}
+	var rowIDs []int
+	for dbRows.Next() {
+		var rowID int
+		if err = dbRows.Scan(&rowID); err != nil {
+			fmt.Println(err)
+		}
+		rowIDs = append(rowIDs, rowID)
+	}
+	dbRows.Close()
+
+	tableColumns := fetchDBTableColumns(tableID, c)
+	var results []DBRowDefEntry
+	for _, rowID := range rowIDs {
+		if dbRow := fetchDBRow(rowID, tableColumns, c); dbRow != nil {
+			results = append(results, *dbRow)
+		}
+	}
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No dbrows matching query were found"})
+	}
+}
+
+func GetDBRow(c *gin.Context) {
+	dbRow := fetchDBRow(idParam(c, "id"), nil, c)
+
+	if dbRow != nil {
+		c.JSON(http.StatusOK, dbRow)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No dbrow with id " + c.Param("id") + " was found"})
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceTupleValue(t *testing.T) {
+	type placed struct {
+		valueIndex int
+		value      any
+	}
+	tests := []struct {
+		name      string
+		tupleSize int
+		values    []placed
+		want      [][]any
+	}{
+		{"single values", 1, []placed{{0, int64(1)}, {1, int64(2)}},
+			[][]any{{int64(1)}, {int64(2)}}},
+		{"pairs", 2, []placed{{0, int64(1)}, {1, "a"}, {2, int64(2)}, {3, "b"}},
+			[][]any{{int64(1), "a"}, {int64(2), "b"}}},
+		{"first value not at the start of a tuple", 2, []placed{{1, "a"}, {2, int64(2)}, {3, "b"}},
+			[][]any{{nil, "a"}, {int64(2), "b"}}},
+		{"gap mid column keeps later values in their tuple", 2, []placed{{0, int64(1)}, {1, "a"}, {3, "b"}},
+			[][]any{{int64(1), "a"}, {nil, "b"}}},
+		{"missing whole tuple", 2, []placed{{0, int64(1)}, {1, "a"}, {4, int64(3)}, {5, "c"}},
+			[][]any{{int64(1), "a"}, {nil, nil}, {int64(3), "c"}}},
+		{"trailing gap", 3, []placed{{0, int64(1)}, {1, "a"}},
+			[][]any{{int64(1), "a", nil}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got [][]any
+			for _, v := range test.values {
+				got = placeTupleValue(got, v.valueIndex, test.tupleSize, v.value)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
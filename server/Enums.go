@@ -0,0 +1,174 @@
+/* Enums.go
+2024, cdfisher
+----------------
+Handlers for enum definitions, returning keys and values typed by the enum's key and value types
+and resolving item, NPC, and object ids to names.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// Tables holding the definitions referenced by ids of each script var type
+var VarTypeTables = map[string]string{
+	"OBJ":      "items",
+	"NAMEDOBJ": "items",
+	"NPC":      "npcs",
+	"LOC":      "objects",
+}
+
+// typedIDs collects ids of each script var type that refer to named definitions, keyed by their table
+type typedIDs map[string][]int
+
+func (ids typedIDs) add(varType string, id int64) {
+	if table, ok := VarTypeTables[varType]; ok && id >= 0 {
+		ids[table] = append(ids[table], int(id))
+	}
+}
+
+// typedNames holds the names of definitions keyed by their table and id
+type typedNames map[string]map[int]string
+
+// fetchTypedNames looks up the names of every collected id with one query per table
+func fetchTypedNames(ids typedIDs, c *gin.Context) typedNames {
+	names := make(typedNames, len(ids))
+	for table, tableIDs := range ids {
+		names[table] = fetchNames(table, tableIDs, c)
+	}
+	return names
+}
+
+// name returns the name of the definition an id of the given type refers to, or an empty string if the type
+// doesn't refer to a named definition
+func (names typedNames) name(varType string, id int64) string {
+	return names[VarTypeTables[varType]][int(id)]
+}
+
+// typedValue returns a stored value as the type given by its script var type
+func typedValue(varType string, intVal sql.NullInt64, stringVal sql.NullString) any {
+	switch varType {
+	case "STRING":
+		return stringVal.String
+	case "BOOLEAN":
+		return intVal.Int64 == 1
+	default:
+		return intVal.Int64
+	}
+}
+
+func fetchEnum(enumID string, c *gin.Context) *EnumDefEntry {
+	enum := EnumDefEntry{}
+	var defaultString sql.NullString
+	var defaultInt sql.NullInt64
+
+	row := db.QueryRowContext(c, "SELECT id, key_type, val_type, default_string, default_int, size FROM enums WHERE id == ?",
+		enumID)
+	err := row.Scan(&enum.ID, &enum.KeyType, &enum.ValType, &defaultString, &defaultInt, &enum.Size)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for enum ", enumID, " : ", err)
+	}
+	enum.Default = typedValue(enum.ValType, defaultInt, defaultString)
+	return &enum
+}
+
+func fetchEnumEntries(enum *EnumDefEntry, query string, args []any, c *gin.Context) []EnumValueEntry {
+	var output []EnumValueEntry
+
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing query for enum ", enum.ID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := EnumValueEntry{}
+		var intVal sql.NullInt64
+		var stringVal sql.NullString
+		err = dbRows.Scan(&rowData.Key, &intVal, &stringVal)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.Value = typedValue(enum.ValType, intVal, stringVal)
+		output = append(output, rowData)
+	}
+
+	nameEnumEntries(enum, output, c)
+	return output
+}
+
+// nameEnumEntries fills in the names of keys and values referring to items, NPCs, or objects
+func nameEnumEntries(enum *EnumDefEntry, entries []EnumValueEntry, c *gin.Context) {
+	ids := typedIDs{}
+	for _, entry := range entries {
+		ids.add(enum.KeyType, entry.Key)
+		ids.add(enum.ValType, enumIntValue(entry.Value))
+	}
+
+	names := fetchTypedNames(ids, c)
+	for i := range entries {
+		entries[i].KeyName = names.name(enum.KeyType, entries[i].Key)
+		entries[i].ValueName = names.name(enum.ValType, enumIntValue(entries[i].Value))
+	}
+}
+
+// enumIntValue returns a typed enum value as an id, or -1 for non-numeric values
+func enumIntValue(value any) int64 {
+	if id, ok := value.(int64); ok {
+		return id
+	}
+	return -1
+}
+
+func GetEnum(c *gin.Context) {
+	enumID := c.Param("id")
+
+	enum := fetchEnum(enumID, c)
+	if enum == nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No enum with id " + enumID + " was found"})
+		return
+	}
+	enum.Entries = fetchEnumEntries(enum,
+		"SELECT key, int_value, string_value FROM enum_entries WHERE enum_id == ? ORDER BY key", []any{enumID}, c)
+
+	c.JSON(http.StatusOK, enum)
+}
+
+// GetEnumLookup returns the value of a single key, falling back to the enum's default as the client does
+func GetEnumLookup(c *gin.Context) {
+	enumID := c.Param("id")
+
+	key, err := strconv.ParseInt(c.Query("key"), 10, 64)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "A numeric key query param is required"})
+		return
+	}
+
+	enum := fetchEnum(enumID, c)
+	if enum == nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No enum with id " + enumID + " was found"})
+		return
+	}
+
+	lookup := EnumLookupEntry{EnumID: enum.ID}
+	entries := fetchEnumEntries(enum,
+		"SELECT key, int_value, string_value FROM enum_entries WHERE enum_id == ? AND key == ?", []any{enumID, key}, c)
+	if len(entries) > 0 {
+		lookup.EnumValueEntry = entries[0]
+	} else {
+		entries = []EnumValueEntry{{Key: key, Value: enum.Default}}
+		nameEnumEntries(enum, entries, c)
+		lookup.EnumValueEntry = entries[0]
+		lookup.IsDefault = true
+	}
+
+	c.JSON(http.StatusOK, lookup)
+}
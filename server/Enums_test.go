@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+var testEnums = []string{
+	"INSERT INTO items (id, name) VALUES (1163, 'Rune full helm'), (4151, 'Abyssal whip')",
+	"INSERT INTO npcs (id, name) VALUES (3, 'Man'), (4, 'Woman')",
+	"INSERT INTO enums VALUES (100, 'INTEGER', 'OBJ', NULL, -1, 3), (101, 'NPC', 'STRING', 'Nobody', NULL, 1)",
+	"INSERT INTO enum_entries VALUES (100, 0, 4151, NULL), (100, 1, 1163, NULL), (100, 2, 99999, NULL), (101, 3, NULL, 'Bob')",
+}
+
+// assertJSON checks that a response body holds the same JSON as want, ignoring formatting
+func assertJSON(t *testing.T, url string, body []byte, want string) {
+	t.Helper()
+	var got, expected any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("%s: %s", url, err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("%s: bad expected JSON: %s", url, err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("%s: got %s\nwant %s", url, body, want)
+	}
+}
+
+func TestGetEnum(t *testing.T) {
+	openTestDB(t, testEnums...)
+
+	recorder := serve(http.MethodGet, "/enums/100", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", recorder.Code, recorder.Body)
+	}
+	assertJSON(t, "/enums/100", recorder.Body.Bytes(), `{"id": 100, "keyType": "INTEGER", "valType": "OBJ",
+		"default": -1, "size": 3, "entries": [
+			{"key": 0, "value": 4151, "valueName": "Abyssal whip"},
+			{"key": 1, "value": 1163, "valueName": "Rune full helm"},
+			{"key": 2, "value": 99999}]}`)
+
+	if recorder = serve(http.MethodGet, "/enums/102", ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("missing enum: got status %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestGetEnumLookup(t *testing.T) {
+	openTestDB(t, testEnums...)
+
+	found := map[string]string{
+		"/enums/100/lookup?key=1": `{"enumId": 100, "key": 1, "value": 1163, "valueName": "Rune full helm",
+			"isDefault": false}`,
+		"/enums/100/lookup?key=9": `{"enumId": 100, "key": 9, "value": -1, "isDefault": true}`,
+		"/enums/101/lookup?key=3": `{"enumId": 101, "key": 3, "keyName": "Man", "value": "Bob", "isDefault": false}`,
+		"/enums/101/lookup?key=4": `{"enumId": 101, "key": 4, "keyName": "Woman", "value": "Nobody",
+			"isDefault": true}`,
+	}
+	for url, want := range found {
+		recorder := serve(http.MethodGet, url, "")
+		if recorder.Code != http.StatusOK {
+			t.Errorf("%s: got status %d: %s", url, recorder.Code, recorder.Body)
+			continue
+		}
+		assertJSON(t, url, recorder.Body.Bytes(), want)
+	}
+
+	failed := map[string]int{
+		"/enums/100/lookup":         http.StatusBadRequest,
+		"/enums/100/lookup?key=one": http.StatusBadRequest,
+		"/enums/102/lookup?key=1":   http.StatusNotFound,
+	}
+	for url, status := range failed {
+		if recorder := serve(http.MethodGet, url, ""); recorder.Code != status {
+			t.Errorf("%s: got status %d, want %d", url, recorder.Code, status)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+/* Equipment.go
+2024, cdfisher
+----------------
+Handlers for querying decoded equipment bonuses and wield requirements.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Columns of equipment_stats that can be filtered on with min_<column> and max_<column>
+var EquipmentStatColumns = []string{
+	"stab_attack",
+	"slash_attack",
+	"crush_attack",
+	"magic_attack",
+	"ranged_attack",
+	"stab_defence",
+	"slash_defence",
+	"crush_defence",
+	"magic_defence",
+	"ranged_defence",
+	"melee_strength",
+	"ranged_strength",
+	"magic_damage",
+	"prayer",
+	"attack_speed",
+}
+
+// Equipment slots as stored in wear_pos_1, wear_pos_2, and wear_pos_3
+const (
+	SlotHead = iota
+	SlotCape
+	SlotAmulet
+	SlotWeapon
+	SlotBody
+	SlotShield
+	SlotArms
+	SlotLegs
+	SlotHair
+	SlotHands
+	SlotFeet
+	SlotJaw
+	SlotRing
+	SlotAmmo
+)
+
+var EquipmentSlotNames = []string{"head", "cape", "amulet", "weapon", "body", "shield", "arms", "legs", "hair", "hands",
+	"feet", "jaw", "ring", "ammo"}
+
+// Slots items can be equipped in. Arms, hair, and jaw are only ever covered through wear_pos_2 and wear_pos_3.
+var EquippableSlots = map[int]bool{SlotHead: true, SlotCape: true, SlotAmulet: true, SlotWeapon: true, SlotBody: true,
+	SlotShield: true, SlotLegs: true, SlotHands: true, SlotFeet: true, SlotRing: true, SlotAmmo: true}
+
+const unarmedAttackSpeed = 4
+
+// slotName returns the name of an equipment slot, or an empty string for -1 and unknown slots
+func slotName(pos int) string {
+	if pos < 0 || pos >= len(EquipmentSlotNames) {
+		return ""
+	}
+	return EquipmentSlotNames[pos]
+}
+
+// parseEquipmentSlot accepts either a slot name or a slot number
+func parseEquipmentSlot(val string) (int, bool) {
+	for i, name := range EquipmentSlotNames {
+		if strings.EqualFold(val, name) {
+			return i, true
+		}
+	}
+	slot, err := strconv.Atoi(val)
+	if err != nil || slot < 0 || slot >= len(EquipmentSlotNames) {
+		return 0, false
+	}
+	return slot, true
+}
+
+const equipmentQuery = "SELECT e.item_id, i.name, e.equip_slot, e.stab_attack, e.slash_attack, e.crush_attack, e.magic_attack, e.ranged_attack, e.stab_defence, e.slash_defence, e.crush_defence, e.magic_defence, e.ranged_defence, e.melee_strength, e.ranged_strength, e.magic_damage, e.prayer, e.attack_speed, e.requirements FROM equipment_stats e JOIN items i ON i.id = e.item_id"
+
+func fetchEquipmentStats(query string, args []any, c *gin.Context) []EquipmentStatsEntry {
+	var output []EquipmentStatsEntry
+
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing equipment query ", args, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := EquipmentStatsEntry{}
+		var requirements string
+		err = dbRows.Scan(&rowData.ItemID, &rowData.Name, &rowData.EquipSlot, &rowData.StabAttack,
+			&rowData.SlashAttack, &rowData.CrushAttack, &rowData.MagicAttack, &rowData.RangedAttack,
+			&rowData.StabDefence, &rowData.SlashDefence, &rowData.CrushDefence, &rowData.MagicDefence,
+			&rowData.RangedDefence, &rowData.MeleeStrength, &rowData.RangedStrength, &rowData.MagicDamage,
+			&rowData.Prayer, &rowData.AttackSpeed, &requirements)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.Requirements = json.RawMessage(requirements)
+		rowData.SlotName = slotName(rowData.EquipSlot)
+		output = append(output, rowData)
+	}
+	return output
+}
+
+// GetItemEquipment returns the equipment stats for a single item id
+func GetItemEquipment(c *gin.Context) {
+	itemID := c.Param("id")
+
+	results := fetchEquipmentStats(equipmentQuery+" WHERE e.item_id == ?", []any{itemID}, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results[0])
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No equipment stats found for item " + itemID})
+	}
+}
+
+// GetEquipment returns every equippable item matching the slot and min_/max_ stat filters in the query string.
+// Slots can be given by name or number.
+func GetEquipment(c *gin.Context) {
+	var conditions []string
+	var args []any
+
+	if slot, ok := c.GetQuery("slot"); ok {
+		slotID, ok := parseEquipmentSlot(slot)
+		if !ok {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid slot %s", slot)})
+			return
+		}
+		conditions = append(conditions, "e.equip_slot == ?")
+		args = append(args, slotID)
+	}
+
+	for _, column := range EquipmentStatColumns {
+		for prefix, op := range map[string]string{"min_": ">=", "max_": "<="} {
+			val, ok := c.GetQuery(prefix + column)
+			if !ok {
+				continue
+			}
+			bound, err := strconv.Atoi(val)
+			if err != nil {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid value %s for %s%s",
+					val, prefix, column)})
+				return
+			}
+			conditions = append(conditions, fmt.Sprintf("e.%s %s ?", column, op))
+			args = append(args, bound)
+		}
+	}
+
+	query := equipmentQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY e.item_id"
+
+	results := fetchEquipmentStats(query, args, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No equipment matching query was found"})
+	}
+}
+
+func (b *EquipmentBonuses) add(other EquipmentBonuses) {
+	b.StabAttack += other.StabAttack
+	b.SlashAttack += other.SlashAttack
+	b.CrushAttack += other.CrushAttack
+	b.MagicAttack += other.MagicAttack
+	b.RangedAttack += other.RangedAttack
+	b.StabDefence += other.StabDefence
+	b.SlashDefence += other.SlashDefence
+	b.CrushDefence += other.CrushDefence
+	b.MagicDefence += other.MagicDefence
+	b.RangedDefence += other.RangedDefence
+	b.MeleeStrength += other.MeleeStrength
+	b.RangedStrength += other.RangedStrength
+	b.MagicDamage += other.MagicDamage
+	b.Prayer += other.Prayer
+}
+
+// PostLoadout sums the bonuses of a set of items keyed by equipment slot and reports any slot conflicts
+func PostLoadout(c *gin.Context) {
+	request := LoadoutRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid loadout: %s", err)})
+		return
+	}
+
+	slots := make([]int, 0, len(request.Slots))
+	itemIDs := make(map[int]int, len(request.Slots))
+	for key, itemID := range request.Slots {
+		slot, ok := parseEquipmentSlot(key)
+		if !ok || !EquippableSlots[slot] {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid equipment slot %s", key)})
+			return
+		}
+		slots = append(slots, slot)
+		itemIDs[slot] = itemID
+	}
+	sort.Ints(slots)
+
+	loadout := LoadoutEntry{Items: []LoadoutItem{}, AttackSpeed: unarmedAttackSpeed, Conflicts: []string{}}
+	occupiedBy := make(map[int]string)
+
+	for _, slot := range slots {
+		itemID := itemIDs[slot]
+		stats := fetchEquipmentStats(equipmentQuery+" WHERE e.item_id == ?", []any{itemID}, c)
+		if len(stats) == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("Item %d cannot be equipped", itemID)})
+			return
+		}
+
+		item := LoadoutItem{Slot: slot, SlotName: slotName(slot), ItemID: itemID, Name: stats[0].Name}
+		row := db.QueryRowContext(c, "SELECT members, wear_pos_2, wear_pos_3 FROM items WHERE id == ?", itemID)
+		if err := row.Scan(&item.Members, &item.WearPos2, &item.WearPos3); err != nil {
+			fmt.Println(err)
+		}
+
+		if stats[0].EquipSlot != slot {
+			loadout.Conflicts = append(loadout.Conflicts, fmt.Sprintf("%s is worn in the %s slot, not the %s slot",
+				item.Name, stats[0].SlotName, item.SlotName))
+		}
+		for _, pos := range []int{slot, item.WearPos2, item.WearPos3} {
+			if !EquippableSlots[pos] {
+				continue
+			}
+			if other, ok := occupiedBy[pos]; ok {
+				loadout.Conflicts = append(loadout.Conflicts, fmt.Sprintf("%s and %s both occupy the %s slot",
+					other, item.Name, slotName(pos)))
+			} else {
+				occupiedBy[pos] = item.Name
+			}
+		}
+
+		loadout.Bonuses.add(stats[0].EquipmentBonuses)
+		if slot == SlotWeapon {
+			loadout.AttackSpeed = stats[0].AttackSpeed
+		}
+		loadout.Members = loadout.Members || item.Members
+		loadout.Items = append(loadout.Items, item)
+	}
+
+	loadout.Valid = len(loadout.Conflicts) == 0
+	c.JSON(http.StatusOK, loadout)
+}
+
+// GetItemsBySlot returns every item worn in the given slot
+func GetItemsBySlot(c *gin.Context) {
+	slot, ok := parseEquipmentSlot(c.Param("slot"))
+	if !ok {
+		notFound(c, c.Param("slot"), "/items/equipment")
+		return
+	}
+
+	query, args, ok := itemVariantQuery(fmt.Sprintf(Queries[1], "items", "wear_pos_1"), []any{slot}, c)
+	if !ok {
+		return
+	}
+	results := fetchItems(query, args, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No items matching query were found"})
+	}
+}
+
+// GetItemsHidingSlot returns every item that covers the given slot through wear_pos_2 or wear_pos_3,
+// such as full helms hiding hair
+func GetItemsHidingSlot(c *gin.Context) {
+	slot, ok := parseEquipmentSlot(c.Param("slot"))
+	if !ok {
+		notFound(c, c.Param("slot"), "/items/hides")
+		return
+	}
+
+	query, args, ok := itemVariantQuery("SELECT * FROM items WHERE wear_pos_2 == ?1 OR wear_pos_3 == ?1 ORDER BY id",
+		[]any{slot}, c)
+	if !ok {
+		return
+	}
+	results := fetchItems(query, args, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No items matching query were found"})
+	}
+}
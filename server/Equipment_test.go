@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+var loadoutItems = []string{
+	"INSERT INTO items (id, name, members, wear_pos_2, wear_pos_3) VALUES (4151, 'Abyssal whip', 1, -1, -1), (1319, 'Rune 2h sword', 0, 5, -1), (1201, 'Rune kiteshield', 0, -1, -1), (1163, 'Rune full helm', 0, 8, 11)",
+	"INSERT INTO equipment_stats (item_id, equip_slot, stab_attack, slash_attack, crush_attack, magic_attack, ranged_attack, stab_defence, slash_defence, crush_defence, magic_defence, ranged_defence, melee_strength, ranged_strength, magic_damage, prayer, attack_speed, requirements) VALUES " +
+		"(4151, 3, 0, 82, 0, 0, 0, 0, 0, 0, 0, 0, 82, 0, 0, 0, 4, '[]'), " +
+		"(1319, 3, 0, 69, 50, -4, 0, 0, 0, 0, 0, 0, 70, 0, 0, 0, 7, '[]'), " +
+		"(1201, 5, 0, 0, 0, -8, -2, 44, 48, 46, -1, 46, 0, 0, 0, 0, 0, '[]'), " +
+		"(1163, 0, 0, 0, 0, -6, -2, 30, 32, 27, -1, 30, 0, 0, 0, 0, 0, '[]')",
+}
+
+func TestPostLoadout(t *testing.T) {
+	openTestDB(t, loadoutItems...)
+
+	tests := []struct {
+		name        string
+		body        string
+		status      int
+		valid       bool
+		members     bool
+		attackSpeed int
+		bonuses     EquipmentBonuses
+		conflicts   []string
+	}{
+		{name: "weapon and shield", body: `{"slots": {"3": 4151, "5": 1201}}`, status: http.StatusOK, valid: true,
+			members: true, attackSpeed: 4,
+			bonuses: EquipmentBonuses{SlashAttack: 82, MagicAttack: -8, RangedAttack: -2, StabDefence: 44,
+				SlashDefence: 48, CrushDefence: 46, MagicDefence: -1, RangedDefence: 46, MeleeStrength: 82},
+			conflicts: []string{}},
+		{name: "slots given by name", body: `{"slots": {"weapon": 4151, "shield": 1201}}`, status: http.StatusOK,
+			valid: true, members: true, attackSpeed: 4,
+			bonuses: EquipmentBonuses{SlashAttack: 82, MagicAttack: -8, RangedAttack: -2, StabDefence: 44,
+				SlashDefence: 48, CrushDefence: 46, MagicDefence: -1, RangedDefence: 46, MeleeStrength: 82},
+			conflicts: []string{}},
+		{name: "two-handed weapon with a shield", body: `{"slots": {"3": 1319, "5": 1201}}`, status: http.StatusOK,
+			attackSpeed: 7,
+			bonuses: EquipmentBonuses{SlashAttack: 69, CrushAttack: 50, MagicAttack: -12, RangedAttack: -2,
+				StabDefence: 44, SlashDefence: 48, CrushDefence: 46, MagicDefence: -1, RangedDefence: 46,
+				MeleeStrength: 70},
+			conflicts: []string{"Rune 2h sword and Rune kiteshield both occupy the shield slot"}},
+		{name: "item in the wrong slot", body: `{"slots": {"3": 1163}}`, status: http.StatusOK, attackSpeed: 0,
+			bonuses: EquipmentBonuses{MagicAttack: -6, RangedAttack: -2, StabDefence: 30, SlashDefence: 32,
+				CrushDefence: 27, MagicDefence: -1, RangedDefence: 30},
+			conflicts: []string{"Rune full helm is worn in the head slot, not the weapon slot"}},
+		{name: "empty loadout is unarmed", body: `{"slots": {}}`, status: http.StatusOK, valid: true,
+			attackSpeed: unarmedAttackSpeed, conflicts: []string{}},
+		{name: "unequippable item", body: `{"slots": {"3": 995}}`, status: http.StatusNotFound},
+		{name: "unknown slot", body: `{"slots": {"6": 4151}}`, status: http.StatusBadRequest},
+		{name: "malformed body", body: `{"slots": [4151]}`, status: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := serve(http.MethodPost, "/equipment/loadout", test.body)
+			if response.Code != test.status {
+				t.Fatalf("status %d, want %d: %s", response.Code, test.status, response.Body)
+			}
+			if test.status != http.StatusOK {
+				return
+			}
+
+			var loadout LoadoutEntry
+			if err := json.Unmarshal(response.Body.Bytes(), &loadout); err != nil {
+				t.Fatal(err)
+			}
+			if loadout.Valid != test.valid || loadout.Members != test.members ||
+				loadout.AttackSpeed != test.attackSpeed {
+				t.Errorf("valid %t, members %t, attack speed %d, want %t, %t, %d", loadout.Valid, loadout.Members,
+					loadout.AttackSpeed, test.valid, test.members, test.attackSpeed)
+			}
+			if loadout.Bonuses != test.bonuses {
+				t.Errorf("bonuses %+v, want %+v", loadout.Bonuses, test.bonuses)
+			}
+			if !reflect.DeepEqual(loadout.Conflicts, test.conflicts) {
+				t.Errorf("conflicts %q, want %q", loadout.Conflicts, test.conflicts)
+			}
+		})
+	}
+}
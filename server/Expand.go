@@ -0,0 +1,267 @@
+/* Expand.go
+2024, cdfisher
+----------------
+Embeds linked definitions into responses for the expand query param, so that e.g. an item's noted
+copy or an NPC's morph targets come back alongside it instead of needing follow-up requests.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const defaultExpandDepth = 1
+const maxExpandDepth = 3
+
+// Selects every definition whose id is in a JSON array of ids
+const idsQuery = "SELECT * FROM %s WHERE id IN (SELECT value FROM json_each(?)) ORDER BY id"
+
+var ItemExpandFields = map[string]bool{"noted": true, "placeholder": true, "bought": true, "count_obj": true}
+var NPCExpandFields = map[string]bool{"configs": true}
+var ObjectExpandFields = map[string]bool{"configs": true}
+
+// parseExpand reads the expand and depth query params, responding with 400 and returning false if either is invalid
+func parseExpand(c *gin.Context, allowed map[string]bool) (map[string]bool, int, bool) {
+	fields := make(map[string]bool)
+	expand := c.Query("expand")
+	if expand == "" {
+		return fields, 0, true
+	}
+
+	for _, field := range strings.Split(expand, ",") {
+		if !allowed[field] {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Cannot expand %s on route %s", field,
+				c.FullPath())})
+			return nil, 0, false
+		}
+		fields[field] = true
+	}
+
+	depth := defaultExpandDepth
+	if val, ok := c.GetQuery("depth"); ok {
+		var err error
+		depth, err = strconv.Atoi(val)
+		if err != nil || depth < 1 || depth > maxExpandDepth {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("depth must be between 1 and %d",
+				maxExpandDepth)})
+			return nil, 0, false
+		}
+	}
+	return fields, depth, true
+}
+
+// parseIntSlice reads an array of ints stored as JSON text. Empty arrays are stored as "null".
+func parseIntSlice(text string) []int {
+	var output []int
+	if err := json.Unmarshal([]byte(text), &output); err != nil {
+		fmt.Printf("Error unmarshalling int array %s : %s\n", text, err)
+	}
+	return output
+}
+
+func fetchItemByID(id int, c *gin.Context) *ItemEntry {
+	results := fetchItems(fmt.Sprintf(Queries[1], "items", "id"), []any{id}, c)
+	if len(results) == 0 {
+		return nil
+	}
+	return &results[0]
+}
+
+func fetchNPCByID(id int, c *gin.Context) *NPCEntry {
+	results := fetchNPCs(fmt.Sprintf(Queries[1], "npcs", "id"), strconv.Itoa(id), c)
+	if len(results) == 0 {
+		return nil
+	}
+	return &results[0]
+}
+
+func fetchObjectByID(id int, c *gin.Context) *ObjectEntry {
+	results := fetchObjects(fmt.Sprintf(Queries[1], "objects", "id"), strconv.Itoa(id), c)
+	if len(results) == 0 {
+		return nil
+	}
+	return &results[0]
+}
+
+// idListArg encodes ids as a JSON array for idsQuery
+func idListArg(ids []int) string {
+	text, err := json.Marshal(ids)
+	if err != nil {
+		fmt.Printf("Error marshalling id list %v : %s\n", ids, err)
+	}
+	return string(text)
+}
+
+// keyByID indexes definitions by id
+func keyByID[T any](entries []T, id func(*T) int) map[int]*T {
+	output := make(map[int]*T, len(entries))
+	for i := range entries {
+		output[id(&entries[i])] = &entries[i]
+	}
+	return output
+}
+
+// fetchNames looks up the names of the given definitions in a table with a single query
+func fetchNames(table string, ids []int, c *gin.Context) map[int]string {
+	output := make(map[int]string, len(ids))
+
+	dbRows, err := db.QueryContext(c, fmt.Sprintf("SELECT id, name FROM %s WHERE id IN (SELECT value FROM "+
+		"json_each(?))", table), idListArg(ids))
+	if err != nil {
+		log.Fatal("Error encountered looking up names in ", table, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		var id int
+		var name string
+		if err = dbRows.Scan(&id, &name); err != nil {
+			fmt.Println(err)
+		}
+		output[id] = name
+	}
+	return output
+}
+
+// expandLink is a field of an entity linking to other definitions of the same type
+type expandLink struct {
+	field string
+	ids   []int
+	list  bool
+}
+
+// expandLevels embeds linked definitions breadth first, looking up everything linked from one level with a single
+// query so that the number of queries depends on depth rather than on the number of results
+func expandLevels[T any](level []*T, depth int, links func(*T) []expandLink, fetch func(ids []int) map[int]*T,
+	setExpanded func(*T, map[string]any)) {
+	for ; depth > 0 && len(level) > 0; depth-- {
+		var ids []int
+		for _, entity := range level {
+			for _, link := range links(entity) {
+				ids = append(ids, link.ids...)
+			}
+		}
+		if len(ids) == 0 {
+			return
+		}
+		linked := fetch(ids)
+
+		var next []*T
+		for _, entity := range level {
+			expanded := make(map[string]any)
+			for _, link := range links(entity) {
+				var found []*T
+				for _, id := range link.ids {
+					if target, ok := linked[id]; ok {
+						// Each embed gets its own copy so it can be expanded separately
+						embedded := *target
+						found = append(found, &embedded)
+					}
+				}
+				if len(found) == 0 {
+					continue
+				}
+				if link.list {
+					expanded[link.field] = found
+				} else {
+					expanded[link.field] = found[0]
+				}
+				next = append(next, found...)
+			}
+			if len(expanded) > 0 {
+				setExpanded(entity, expanded)
+			}
+		}
+		level = next
+	}
+}
+
+func expandItems(items []ItemEntry, fields map[string]bool, depth int, c *gin.Context) {
+	if len(fields) == 0 {
+		return
+	}
+	level := make([]*ItemEntry, len(items))
+	for i := range items {
+		level[i] = &items[i]
+	}
+
+	links := func(item *ItemEntry) []expandLink {
+		var output []expandLink
+		singles := map[string]int{"noted": item.NotedID, "placeholder": item.PlaceholderID, "bought": item.BoughtID}
+		for field, id := range singles {
+			if fields[field] && id != -1 {
+				output = append(output, expandLink{field: field, ids: []int{id}})
+			}
+		}
+		if fields["count_obj"] {
+			var stacks []int
+			for _, id := range parseIntSlice(item.CountObj) {
+				// Unused stack variants are 0
+				if id > 0 {
+					stacks = append(stacks, id)
+				}
+			}
+			output = append(output, expandLink{field: "count_obj", ids: stacks, list: true})
+		}
+		return output
+	}
+	fetch := func(ids []int) map[int]*ItemEntry {
+		return keyByID(fetchItems(fmt.Sprintf(idsQuery, "items"), []any{idListArg(ids)}, c),
+			func(item *ItemEntry) int { return item.ID })
+	}
+	expandLevels(level, depth, links, fetch, func(item *ItemEntry, expanded map[string]any) {
+		item.Expanded = expanded
+	})
+}
+
+// morphLinks links an entity's morph targets, skipping the hidden (-1) state
+func morphLinks(configs string) []expandLink {
+	var ids []int
+	for _, id := range parseIntSlice(configs) {
+		if id != -1 {
+			ids = append(ids, id)
+		}
+	}
+	return []expandLink{{field: "configs", ids: ids, list: true}}
+}
+
+func expandNPCs(npcs []NPCEntry, fields map[string]bool, depth int, c *gin.Context) {
+	if !fields["configs"] {
+		return
+	}
+	level := make([]*NPCEntry, len(npcs))
+	for i := range npcs {
+		level[i] = &npcs[i]
+	}
+
+	fetch := func(ids []int) map[int]*NPCEntry {
+		return keyByID(fetchNPCs(fmt.Sprintf(idsQuery, "npcs"), idListArg(ids), c),
+			func(npc *NPCEntry) int { return npc.ID })
+	}
+	expandLevels(level, depth, func(npc *NPCEntry) []expandLink { return morphLinks(npc.Configs) }, fetch,
+		func(npc *NPCEntry, expanded map[string]any) { npc.Expanded = expanded })
+}
+
+func expandObjects(objects []ObjectEntry, fields map[string]bool, depth int, c *gin.Context) {
+	if !fields["configs"] {
+		return
+	}
+	level := make([]*ObjectEntry, len(objects))
+	for i := range objects {
+		level[i] = &objects[i]
+	}
+
+	fetch := func(ids []int) map[int]*ObjectEntry {
+		return keyByID(fetchObjects(fmt.Sprintf(idsQuery, "objects"), idListArg(ids), c),
+			func(object *ObjectEntry) int { return object.ID })
+	}
+	expandLevels(level, depth, func(object *ObjectEntry) []expandLink { return morphLinks(object.ConfigChangeDest) },
+		fetch, func(object *ObjectEntry, expanded map[string]any) { object.Expanded = expanded })
+}
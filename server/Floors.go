@@ -0,0 +1,110 @@
+/* Floors.go
+2024, cdfisher
+----------------
+Handlers for floor underlay and overlay definitions, returning each colour as stored along with
+its hex and HSL forms.
+
+Overlays coloured #ff00ff are drawn without a colour of their own, showing only their texture.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+func fetchUnderlays(query string, args []any, c *gin.Context) []UnderlayDefEntry {
+	var output []UnderlayDefEntry
+
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing underlay query ", args, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := UnderlayDefEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.Color)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.ColorHex = rgbToHex(rowData.Color)
+		rowData.ColorHSL = rgbToHSL(rowData.Color)
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func fetchOverlays(query string, args []any, c *gin.Context) []OverlayDefEntry {
+	var output []OverlayDefEntry
+
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing overlay query ", args, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := OverlayDefEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.Color, &rowData.Texture, &rowData.SecondaryColor,
+			&rowData.HideUnderlay)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.ColorHex = rgbToHex(rowData.Color)
+		rowData.ColorHSL = rgbToHSL(rowData.Color)
+		// -1 means the overlay has no secondary colour
+		if rowData.SecondaryColor != -1 {
+			secondaryHSL := rgbToHSL(rowData.SecondaryColor)
+			rowData.SecondaryColorHex = rgbToHex(rowData.SecondaryColor)
+			rowData.SecondaryColorHSL = &secondaryHSL
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func GetUnderlays(c *gin.Context) {
+	results := fetchUnderlays("SELECT id, color FROM underlays ORDER BY id", nil, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No underlays were found"})
+	}
+}
+
+func GetUnderlay(c *gin.Context) {
+	results := fetchUnderlays("SELECT id, color FROM underlays WHERE id == ?", []any{idParam(c, "id")}, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results[0])
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No underlay with id " + c.Param("id") + " was found"})
+	}
+}
+
+func GetOverlays(c *gin.Context) {
+	results := fetchOverlays("SELECT id, color, texture, secondary_color, hide_underlay FROM overlays ORDER BY id",
+		nil, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No overlays were found"})
+	}
+}
+
+func GetOverlay(c *gin.Context) {
+	results := fetchOverlays("SELECT id, color, texture, secondary_color, hide_underlay FROM overlays WHERE id == ?",
+		[]any{idParam(c, "id")}, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results[0])
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No overlay with id " + c.Param("id") + " was found"})
+	}
+}
@@ -0,0 +1,111 @@
+/* Interfaces.go
+2024, cdfisher
+----------------
+Handlers for interface (widget) definitions, returning a group's components as a tree and
+searching component text and actions.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// Names of the component types in use. Type 1 is unused.
+var InterfaceTypeNames = map[int]string{
+	0: "layer",
+	2: "inventory",
+	3: "rectangle",
+	4: "text",
+	5: "graphic",
+	6: "model",
+	7: "text_inventory",
+	8: "tooltip",
+	9: "line",
+}
+
+const interfaceQuery = "SELECT id, group_id, component_id, parent_id, is_if3, type, content_type, x, y, width, height, is_hidden, sprite_id, model_type, model_id, font_id, text, text_color, name, actions, item_ids, listeners FROM interfaces"
+
+func fetchInterfaceComponents(query string, args []any, c *gin.Context) []InterfaceComponentEntry {
+	var output []InterfaceComponentEntry
+
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing interface query ", args, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := InterfaceComponentEntry{}
+		var actions, itemIDs, listeners string
+		err = dbRows.Scan(&rowData.ID, &rowData.Group, &rowData.Component, &rowData.ParentID, &rowData.IsIf3,
+			&rowData.Type, &rowData.ContentType, &rowData.X, &rowData.Y, &rowData.Width, &rowData.Height,
+			&rowData.IsHidden, &rowData.SpriteID, &rowData.ModelType, &rowData.ModelID, &rowData.FontID, &rowData.Text,
+			&rowData.TextColor, &rowData.Name, &actions, &itemIDs, &listeners)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.TypeName = InterfaceTypeNames[rowData.Type]
+		rowData.Actions = json.RawMessage(actions)
+		rowData.ItemIDs = json.RawMessage(itemIDs)
+		rowData.Listeners = json.RawMessage(listeners)
+		output = append(output, rowData)
+	}
+	return output
+}
+
+// buildInterfaceTree nests each component under its parent. Components without a parent in the group are roots.
+func buildInterfaceTree(components []InterfaceComponentEntry) []*InterfaceComponentEntry {
+	nodes := make(map[int]*InterfaceComponentEntry, len(components))
+	for i := range components {
+		nodes[components[i].ID] = &components[i]
+	}
+
+	var roots []*InterfaceComponentEntry
+	for i := range components {
+		component := &components[i]
+		if parent, ok := nodes[component.ParentID]; ok && parent != component {
+			parent.Children = append(parent.Children, component)
+		} else {
+			roots = append(roots, component)
+		}
+	}
+	return roots
+}
+
+func GetInterface(c *gin.Context) {
+	group := idParam(c, "group")
+
+	components := fetchInterfaceComponents(interfaceQuery+" WHERE group_id == ? ORDER BY component_id", []any{group},
+		c)
+	if len(components) == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No interface with group " + c.Param("group") +
+			" was found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, InterfaceTreeEntry{Group: group, ComponentCount: len(components),
+		Components: buildInterfaceTree(components)})
+}
+
+// SearchInterfaces returns every component whose text, name, or actions contain the text query param
+func SearchInterfaces(c *gin.Context) {
+	text := c.Query("text")
+	if text == "" {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "A text query param is required"})
+		return
+	}
+
+	query := interfaceQuery + " WHERE text LIKE '%' || ? || '%' OR name LIKE '%' || ? || '%' OR actions LIKE '%' || ? || '%' ORDER BY id"
+	results := fetchInterfaceComponents(query, []any{text, text, text}, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No interface components matching query were found"})
+	}
+}
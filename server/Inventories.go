@@ -0,0 +1,81 @@
+/* Inventories.go
+2024, cdfisher
+----------------
+Handlers for inventory definitions and their default stock, and for looking up the inventories
+(e.g. shops) stocking an item.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+func fetchInventoryStock(inventoryID int, c *gin.Context) []InventoryStockEntry {
+	output := []InventoryStockEntry{}
+
+	query := "SELECT s.slot, s.item_id, COALESCE(i.name, ''), s.count FROM inventory_stock s LEFT JOIN items i ON i.id = s.item_id WHERE s.inventory_id == ? ORDER BY s.slot"
+	dbRows, err := db.QueryContext(c, query, inventoryID)
+	if err != nil {
+		log.Fatal("Error encountered executing stock query for inventory ", inventoryID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := InventoryStockEntry{}
+		err = dbRows.Scan(&rowData.Slot, &rowData.ItemID, &rowData.Name, &rowData.Count)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func GetInventory(c *gin.Context) {
+	inventory := InventoryDefEntry{}
+
+	row := db.QueryRowContext(c, "SELECT id, size FROM inventories WHERE id == ?", idParam(c, "id"))
+	err := row.Scan(&inventory.ID, &inventory.Size)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No inventory with id " + c.Param("id") + " was found"})
+		return
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for inventory ", c.Param("id"), " : ", err)
+	}
+	inventory.Stock = fetchInventoryStock(inventory.ID, c)
+
+	c.JSON(http.StatusOK, inventory)
+}
+
+// GetItemInventories lists every inventory with an item in its default stock
+func GetItemInventories(c *gin.Context) {
+	itemID := c.Param("id")
+	var results []ItemInventoryEntry
+
+	query := "SELECT s.inventory_id, n.size, s.slot, s.count FROM inventory_stock s JOIN inventories n ON n.id = s.inventory_id WHERE s.item_id == ? ORDER BY s.inventory_id, s.slot"
+	dbRows, err := db.QueryContext(c, query, itemID)
+	if err != nil {
+		log.Fatal("Error encountered executing inventory query for item ", itemID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := ItemInventoryEntry{}
+		err = dbRows.Scan(&rowData.InventoryID, &rowData.Size, &rowData.Slot, &rowData.Count)
+		if err != nil {
+			fmt.Println(err)
+		}
+		results = append(results, rowData)
+	}
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No inventories stocking item " + itemID + " were found"})
+	}
+}
@@ -0,0 +1,133 @@
+/* Kits.go
+2024, cdfisher
+----------------
+Handlers for identity kits, the body parts making up a player's default appearance. Body parts
+0-6 are male and 7-13 are the matching female parts.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var BodyPartNames = []string{"hair", "jaw", "torso", "arms", "hands", "legs", "feet"}
+
+const kitQuery = "SELECT id, body_part_id, models, chathead_models, recolor_to_find, recolor_to_replace, retexture_to_find, retexture_to_replace, non_selectable FROM kits"
+
+// bodyPartName returns the name and gender of a kit body part, or empty strings for -1 and unknown parts
+func bodyPartName(bodyPart int) (string, string) {
+	if bodyPart < 0 || bodyPart >= 2*len(BodyPartNames) {
+		return "", ""
+	}
+	if bodyPart < len(BodyPartNames) {
+		return BodyPartNames[bodyPart], "male"
+	}
+	return BodyPartNames[bodyPart-len(BodyPartNames)], "female"
+}
+
+// parseBodyPart accepts either a body part number, matching only that part, or a name, matching the part for both
+// genders
+func parseBodyPart(val string) ([]int, bool) {
+	for i, name := range BodyPartNames {
+		if strings.EqualFold(val, name) {
+			return []int{i, i + len(BodyPartNames)}, true
+		}
+	}
+	bodyPart, err := strconv.Atoi(val)
+	if err != nil || bodyPart < 0 || bodyPart >= 2*len(BodyPartNames) {
+		return nil, false
+	}
+	return []int{bodyPart}, true
+}
+
+func fetchKits(query string, args []any, c *gin.Context) []KitDefEntry {
+	var output []KitDefEntry
+
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing kit query ", args, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := KitDefEntry{}
+		var models, chatheadModels, recolorToFind, recolorToReplace, retextureToFind, retextureToReplace string
+		err = dbRows.Scan(&rowData.ID, &rowData.BodyPartID, &models, &chatheadModels, &recolorToFind,
+			&recolorToReplace, &retextureToFind, &retextureToReplace, &rowData.NonSelectable)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.BodyPart, rowData.Gender = bodyPartName(rowData.BodyPartID)
+		rowData.Models = json.RawMessage(models)
+		rowData.ChatheadModels = json.RawMessage(chatheadModels)
+		rowData.RecolorToFind = json.RawMessage(recolorToFind)
+		rowData.RecolorToReplace = json.RawMessage(recolorToReplace)
+		rowData.RetextureToFind = json.RawMessage(retextureToFind)
+		rowData.RetextureToReplace = json.RawMessage(retextureToReplace)
+		if c.Query("colors") == "rgb" {
+			rowData.RecolorToFindRGB = decodeColors(recolorToFind)
+			rowData.RecolorToReplaceRGB = decodeColors(recolorToReplace)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+// GetKits lists every kit, optionally filtered by the body_part and selectable query params
+func GetKits(c *gin.Context) {
+	var conditions []string
+	var args []any
+
+	if val, ok := c.GetQuery("body_part"); ok {
+		bodyParts, ok := parseBodyPart(val)
+		if !ok {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid body part %s", val)})
+			return
+		}
+		placeholders := make([]string, len(bodyParts))
+		for i, bodyPart := range bodyParts {
+			placeholders[i] = "?"
+			args = append(args, bodyPart)
+		}
+		conditions = append(conditions, fmt.Sprintf("body_part_id IN (%s)", strings.Join(placeholders, ", ")))
+	}
+
+	if val, ok := c.GetQuery("selectable"); ok {
+		selectable, err := strconv.ParseBool(val)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid selectable %s", val)})
+			return
+		}
+		conditions = append(conditions, "non_selectable == ?")
+		args = append(args, !selectable)
+	}
+
+	query := kitQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	results := fetchKits(query+" ORDER BY id", args, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No kits matching query were found"})
+	}
+}
+
+func GetKit(c *gin.Context) {
+	results := fetchKits(kitQuery+" WHERE id == ?", []any{idParam(c, "id")}, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results[0])
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No kit with id " + c.Param("id") + " was found"})
+	}
+}
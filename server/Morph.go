@@ -0,0 +1,157 @@
+/* Morph.go
+2024, cdfisher
+----------------
+Handlers for resolving multi-state NPCs and objects. Their configs/config_change_dest arrays
+hold the definition shown for each value of the controlling varbit or varp, with the last entry
+used for any value past the end of the array. A target of -1 hides the entity.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// morphTargetID returns the definition id displayed for the given var value, as the client does
+func morphTargetID(configs []int, value int) int {
+	if len(configs) == 0 {
+		return -1
+	}
+	if value >= 0 && value < len(configs)-1 {
+		return configs[value]
+	}
+	return configs[len(configs)-1]
+}
+
+// parseMorphValue reads the value query param and checks any varbit or varp param against the var controlling
+// the entity, responding with 400 and returning false if they don't line up. For an entity controlled by a varbit,
+// varp can instead name the varbit's base varp, in which case value is the varp's value.
+func parseMorphValue(c *gin.Context, varbitID int, varpID int) (int, bool) {
+	if varbit, ok := c.GetQuery("varbit"); ok && varbit != strconv.Itoa(varbitID) {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf(
+			"Not controlled by varbit %s (varbit %d, varp %d)", varbit, varbitID, varpID)})
+		return 0, false
+	}
+
+	value, err := strconv.ParseInt(c.Query("value"), 10, 32)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "A numeric value query param is required"})
+		return 0, false
+	}
+
+	if varp, ok := c.GetQuery("varp"); ok && varp != strconv.Itoa(varpID) {
+		if varbitID != -1 {
+			if varbit := fetchVarbitByID(varbitID, c); varbit != nil && strconv.Itoa(varbit.BaseVar) == varp {
+				return varbit.valueFrom(int32(value)), true
+			}
+		}
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf(
+			"Not controlled by varp %s (varbit %d, varp %d)", varp, varbitID, varpID)})
+		return 0, false
+	}
+	return int(value), true
+}
+
+// buildMorphTargets lists the target of every state, naming them from a map of target names
+func buildMorphTargets(configs []int, names map[int]string) []MorphTarget {
+	targets := make([]MorphTarget, 0, len(configs))
+	for i, id := range configs {
+		target := MorphTarget{Value: i, ID: id}
+		if i == len(configs)-1 {
+			target.Value = -1
+			target.Default = true
+		}
+		if id != -1 {
+			target.Name = names[id]
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+func GetNPCMorph(c *gin.Context) {
+	npc := fetchNPCByID(idParam(c, "key"), c)
+	if npc == nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No NPCs matching query were found"})
+		return
+	}
+	configs := parseIntSlice(npc.Configs)
+	if len(configs) == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("NPC %d has no morphs", npc.ID)})
+		return
+	}
+
+	value, ok := parseMorphValue(c, npc.VarbitID, npc.VarpIndex)
+	if !ok {
+		return
+	}
+
+	morph := MorphEntry{ID: npc.ID, VarbitID: npc.VarbitID, VarpID: npc.VarpIndex, Value: value}
+	morph.TargetID = morphTargetID(configs, value)
+	if morph.TargetID != -1 {
+		morph.Target = fetchNPCByID(morph.TargetID, c)
+	}
+	c.JSON(http.StatusOK, morph)
+}
+
+func GetNPCMorphs(c *gin.Context) {
+	npc := fetchNPCByID(idParam(c, "key"), c)
+	if npc == nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No NPCs matching query were found"})
+		return
+	}
+	configs := parseIntSlice(npc.Configs)
+	if len(configs) == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("NPC %d has no morphs", npc.ID)})
+		return
+	}
+
+	morphs := MorphListEntry{ID: npc.ID, Name: npc.Name, VarbitID: npc.VarbitID, VarpID: npc.VarpIndex}
+	morphs.Morphs = buildMorphTargets(configs, fetchNames("npcs", configs, c))
+	c.JSON(http.StatusOK, morphs)
+}
+
+func GetObjectMorph(c *gin.Context) {
+	object := fetchObjectByID(idParam(c, "key"), c)
+	if object == nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No objects matching query were found"})
+		return
+	}
+	configs := parseIntSlice(object.ConfigChangeDest)
+	if len(configs) == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("Object %d has no morphs", object.ID)})
+		return
+	}
+
+	value, ok := parseMorphValue(c, object.VarbitID, object.VarpID)
+	if !ok {
+		return
+	}
+
+	morph := MorphEntry{ID: object.ID, VarbitID: object.VarbitID, VarpID: object.VarpID, Value: value}
+	morph.TargetID = morphTargetID(configs, value)
+	if morph.TargetID != -1 {
+		morph.Target = fetchObjectByID(morph.TargetID, c)
+	}
+	c.JSON(http.StatusOK, morph)
+}
+
+func GetObjectMorphs(c *gin.Context) {
+	object := fetchObjectByID(idParam(c, "key"), c)
+	if object == nil {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No objects matching query were found"})
+		return
+	}
+	configs := parseIntSlice(object.ConfigChangeDest)
+	if len(configs) == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("Object %d has no morphs", object.ID)})
+		return
+	}
+
+	morphs := MorphListEntry{ID: object.ID, Name: object.Name, VarbitID: object.VarbitID, VarpID: object.VarpID}
+	morphs.Morphs = buildMorphTargets(configs, fetchNames("objects", configs, c))
+	c.JSON(http.StatusOK, morphs)
+}
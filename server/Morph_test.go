@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMorphTargetID(t *testing.T) {
+	configs := []int{10, 11, 12}
+	tests := map[string]struct {
+		configs []int
+		value   int
+		want    int
+	}{
+		"no configs":                           {nil, 0, -1},
+		"first state":                          {configs, 0, 10},
+		"middle state":                         {configs, 1, 11},
+		"last entry is the fallback":           {configs, 2, 12},
+		"value past the end uses the fallback": {configs, 5, 12},
+		"negative value uses the fallback":     {configs, -1, 12},
+		"hidden state":                         {[]int{-1, 11, 12}, 0, -1},
+		"only a fallback":                      {[]int{7}, 0, 7},
+	}
+	for name, test := range tests {
+		if got := morphTargetID(test.configs, test.value); got != test.want {
+			t.Errorf("%s: morphTargetID(%v, %d) = %d, want %d", name, test.configs, test.value, got, test.want)
+		}
+	}
+}
+
+func TestBuildMorphTargets(t *testing.T) {
+	names := map[int]string{3410: "Guard", 3411: "Guard (hostile)"}
+
+	got := buildMorphTargets([]int{3410, -1, 3411}, names)
+	want := []MorphTarget{
+		{Value: 0, ID: 3410, Name: "Guard"},
+		{Value: 1, ID: -1},
+		{Value: -1, Default: true, ID: 3411, Name: "Guard (hostile)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildMorphTargets = %+v, want %+v", got, want)
+	}
+}
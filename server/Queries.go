@@ -54,6 +54,8 @@ var ItemQueryTypes = map[string]int{
 	"texture_find":            3,
 	"texture_replace":         3,
 	"category":                1,
+	"variant":                 1,
+	"base_id":                 1,
 }
 
 var NPCQueryTypes = map[string]int{
@@ -159,3 +161,52 @@ var Queries = map[int]string{
 	2: "SELECT * FROM %s WHERE %s LIKE '%%' || ? || '%%' COLLATE NOCASE ORDER BY id",
 	3: "", // Should be unreachable
 }
+
+var SpotAnimQueryTypes = map[string]int{
+	"id":                 1,
+	"model_id":           1,
+	"animation_id":       1,
+	"resize_x":           1,
+	"resize_y":           1,
+	"rotation":           1,
+	"ambient":            1,
+	"contrast":           1,
+	"recolor_to_find":    2,
+	"recolor_to_replace": 2,
+	"texture_to_find":    2,
+	"texture_to_replace": 2,
+}
+
+var HitsplatQueryTypes = map[string]int{
+	"id":                 1,
+	"string_format":      2,
+	"font_type":          1,
+	"text_color":         1,
+	"text_offset_y":      1,
+	"left_sprite":        1,
+	"left_sprite_2":      1,
+	"right_sprite_id":    1,
+	"background_sprite":  1,
+	"use_damage":         1,
+	"display_cycles":     1,
+	"fade_start_cycle":   1,
+	"scroll_to_offset_x": 1,
+	"scroll_to_offset_y": 1,
+	"varbit_id":          1,
+	"varp_id":            1,
+	"multihitsplats":     2,
+}
+
+var HealthBarQueryTypes = map[string]int{
+	"id":              1,
+	"front_sprite_id": 1,
+	"back_sprite_id":  1,
+	"health_scale":    1,
+	"padding":         1,
+	"field_3276":      1,
+	"field_3277":      1,
+	"field_3278":      1,
+	"field_3283":      1,
+	"field_3272":      1,
+	"field_3275":      1,
+}
@@ -16,7 +16,7 @@ ResponseEntries TODOs:
 - Arrays of ints are currently being marshalled into strings. Marshal them into arrays of ints
 - Marshal string arrays into arrays of strings instead of single strings
 - Add handling for unknown keys
-- Add support for other objects in cache: dbtables, param_defs, ???
+- Add support for other objects in cache: ???
 - Update Params fields to use generics or reflection rather than interface{}
 - Reorder fields to match appearance in defs
 
@@ -24,156 +24,570 @@ ResponseEntries TODOs:
 
 package main
 
+import "encoding/json"
+
 type ItemEntry struct {
-	ID                    int    `json:"id"`
-	Name                  string `json:"name"`
-	Examine               string `json:"examine"`
-	ResizeX               int    `json:"resizeX"`
-	ResizeY               int    `json:"resizeY"`
-	ResizeZ               int    `json:"resizeZ"`
-	Xan2D                 int    `json:"xan2D"`
-	Yan2D                 int    `json:"yan2D"`
-	Zan2D                 int    `json:"zan2D"`
-	Cost                  int    `json:"cost"`
-	IsTradable            bool   `json:"isTradable"`
-	Stackable             int    `json:"stackable"`
-	InventoryModel        int    `json:"inventoryModel"`
-	WearPos1              int    `json:"wearPos1"`
-	WearPos2              int    `json:"wearPos2"`
-	WearPos3              int    `json:"wearPos3"`
-	Members               bool   `json:"members"`
-	Zoom2D                int    `json:"zoom2D"`
-	XOffset2D             int    `json:"xOffset2d"`
-	YOffset2D             int    `json:"yOffset2d"`
-	Ambient               int    `json:"ambient"`
-	Contrast              int    `json:"contrast"`
-	Options               string `json:"options"`
-	InterfaceOptions      string `json:"interfaceOptions"`
-	MaleModel0            int    `json:"maleModel0"`
-	MaleModel1            int    `json:"maleModel1"`
-	MaleModel2            int    `json:"maleModel2"`
-	MaleOffset            int    `json:"maleOffset"`
-	MaleHeadModel         int    `json:"maleHeadModel"`
-	MaleHeadModel2        int    `json:"maleHeadModel2"`
-	FemaleModel0          int    `json:"femaleModel0"`
-	FemaleModel1          int    `json:"femaleModel1"`
-	FemaleModel2          int    `json:"femaleModel2"`
-	FemaleOffset          int    `json:"femaleOffset"`
-	FemaleHeadModel       int    `json:"femaleHeadModel"`
-	FemaleHeadModel2      int    `json:"femaleHeadModel2"`
-	NotedID               int    `json:"notedID"`
-	NotedTemplate         int    `json:"notedTemplate"`
-	Team                  int    `json:"team"`
-	Weight                int    `json:"weight"`
-	ShiftClickDropIndex   int    `json:"shiftClickDropIndex"`
-	BoughtID              int    `json:"boughtId"`
-	BoughtTemplateID      int    `json:"boughtTemplateId"`
-	PlaceholderID         int    `json:"placeholderId"`
-	PlaceholderTemplateID int    `json:"placeholderTemplateId"`
-	ColorFind             string `json:"colorFind"`
-	ColorReplace          string `json:"colorReplace"`
-	Params                string `json:"params"`
-	CountCo               string `json:"countCo"`
-	CountObj              string `json:"countObj"`
-	TextureFind           string `json:"textureFind"`
-	TextureReplace        string `json:"textureReplace"`
-	Category              int    `json:"category"`
+	ID                    int            `json:"id"`
+	Name                  string         `json:"name"`
+	Examine               string         `json:"examine"`
+	ResizeX               int            `json:"resizeX"`
+	ResizeY               int            `json:"resizeY"`
+	ResizeZ               int            `json:"resizeZ"`
+	Xan2D                 int            `json:"xan2D"`
+	Yan2D                 int            `json:"yan2D"`
+	Zan2D                 int            `json:"zan2D"`
+	Cost                  int            `json:"cost"`
+	IsTradable            bool           `json:"isTradable"`
+	Stackable             int            `json:"stackable"`
+	InventoryModel        int            `json:"inventoryModel"`
+	WearPos1              int            `json:"wearPos1"`
+	WearPos2              int            `json:"wearPos2"`
+	WearPos3              int            `json:"wearPos3"`
+	WearSlot1             string         `json:"wearSlot1,omitempty"`
+	WearSlot2             string         `json:"wearSlot2,omitempty"`
+	WearSlot3             string         `json:"wearSlot3,omitempty"`
+	Members               bool           `json:"members"`
+	Zoom2D                int            `json:"zoom2D"`
+	XOffset2D             int            `json:"xOffset2d"`
+	YOffset2D             int            `json:"yOffset2d"`
+	Ambient               int            `json:"ambient"`
+	Contrast              int            `json:"contrast"`
+	Options               string         `json:"options"`
+	InterfaceOptions      string         `json:"interfaceOptions"`
+	MaleModel0            int            `json:"maleModel0"`
+	MaleModel1            int            `json:"maleModel1"`
+	MaleModel2            int            `json:"maleModel2"`
+	MaleOffset            int            `json:"maleOffset"`
+	MaleHeadModel         int            `json:"maleHeadModel"`
+	MaleHeadModel2        int            `json:"maleHeadModel2"`
+	FemaleModel0          int            `json:"femaleModel0"`
+	FemaleModel1          int            `json:"femaleModel1"`
+	FemaleModel2          int            `json:"femaleModel2"`
+	FemaleOffset          int            `json:"femaleOffset"`
+	FemaleHeadModel       int            `json:"femaleHeadModel"`
+	FemaleHeadModel2      int            `json:"femaleHeadModel2"`
+	NotedID               int            `json:"notedID"`
+	NotedTemplate         int            `json:"notedTemplate"`
+	Team                  int            `json:"team"`
+	Weight                int            `json:"weight"`
+	ShiftClickDropIndex   int            `json:"shiftClickDropIndex"`
+	BoughtID              int            `json:"boughtId"`
+	BoughtTemplateID      int            `json:"boughtTemplateId"`
+	PlaceholderID         int            `json:"placeholderId"`
+	PlaceholderTemplateID int            `json:"placeholderTemplateId"`
+	ColorFind             string         `json:"colorFind"`
+	ColorReplace          string         `json:"colorReplace"`
+	Params                string         `json:"params"`
+	CountCo               string         `json:"countCo"`
+	CountObj              string         `json:"countObj"`
+	TextureFind           string         `json:"textureFind"`
+	TextureReplace        string         `json:"textureReplace"`
+	Category              int            `json:"category"`
+	Variant               string         `json:"variant"`
+	BaseID                int            `json:"baseId"`
+	ColorFindRGB          []string       `json:"colorFindRgb,omitempty"`
+	ColorReplaceRGB       []string       `json:"colorReplaceRgb,omitempty"`
+	Expanded              map[string]any `json:"expanded,omitempty"`
 }
 
 type NPCEntry struct {
-	ID                        int    `json:"id"`
-	Name                      string `json:"name"`
-	Size                      int    `json:"size"`
-	Models                    string `json:"models"`
-	ChatheadModels            string `json:"chatheadModels"`
-	StandingAnimation         int    `json:"standingAnimation"`
-	IdleRotateLeftAnimation   int    `json:"idleRotateLeftAnimation"`
-	IdleRotateRightAnimation  int    `json:"idleRotateRightAnimation"`
-	WalkingAnimation          int    `json:"walkingAnimation"`
-	RotateLeftAnimation       int    `json:"rotateLeftAnimation"`
-	RotateRightAnimation      int    `json:"rotateRightAnimation"`
-	RunAnimation              int    `json:"runAnimation"`
-	RunRotate180Animation     int    `json:"runRotate180Animation"`
-	RunRotateLeftAnimation    int    `json:"runRotateLeftAnimation"`
-	RunRotateRightAnimation   int    `json:"runRotateRightAnimation"`
-	CrawlAnimation            int    `json:"crawlAnimation"`
-	CrawlRotate180Animation   int    `json:"crawlRotate180Animation"`
-	CrawlRotateLeftAnimation  int    `json:"crawlRotateLeftAnimation"`
-	CrawlRotateRightAnimation int    `json:"crawlRotateRightAnimation"`
-	Actions                   string `json:"actions"`
-	IsMinimapVisible          bool   `json:"isMinimapVisible"`
-	CombatLevel               int    `json:"combatLevel"`
-	WidthScale                int    `json:"widthScale"`
-	HeightScale               int    `json:"heightScale"`
-	HasRenderPriority         bool   `json:"hasRenderPriority"`
-	Ambient                   int    `json:"ambient"`
-	Contrast                  int    `json:"contrast"`
-	HeadIconSpriteIndex       string `json:"headIconSpriteIndex"`
-	HeadIconArchiveIDs        string `json:"headIconArchiveIds"`
-	RotationSpeed             int    `json:"rotationSpeed"`
-	VarbitID                  int    `json:"varbitId"`
-	VarpIndex                 int    `json:"varpIndex"`
-	IsInteractable            bool   `json:"isInteractable"`
-	RotationFlag              bool   `json:"rotationFlag"`
-	IsPet                     bool   `json:"isPet"`
-	Configs                   string `json:"configs"`
-	Params                    string `json:"params"`
-	Category                  int    `json:"category"`
-	RecolorToFind             string `json:"recolorToFind"`
-	RecolorToReplace          string `json:"recolorToReplace"`
-	RetextureToFind           string `json:"retextureToFind"`
-	RetextureToReplace        string `json:"retextureToReplace"`
-	IsFollower                bool   `json:"isFollower"`
-	LowPriorityFollowerOps    bool   `json:"lowPriorityFollowerOps"`
+	ID                        int            `json:"id"`
+	Name                      string         `json:"name"`
+	Size                      int            `json:"size"`
+	Models                    string         `json:"models"`
+	ChatheadModels            string         `json:"chatheadModels"`
+	StandingAnimation         int            `json:"standingAnimation"`
+	IdleRotateLeftAnimation   int            `json:"idleRotateLeftAnimation"`
+	IdleRotateRightAnimation  int            `json:"idleRotateRightAnimation"`
+	WalkingAnimation          int            `json:"walkingAnimation"`
+	RotateLeftAnimation       int            `json:"rotateLeftAnimation"`
+	RotateRightAnimation      int            `json:"rotateRightAnimation"`
+	RunAnimation              int            `json:"runAnimation"`
+	RunRotate180Animation     int            `json:"runRotate180Animation"`
+	RunRotateLeftAnimation    int            `json:"runRotateLeftAnimation"`
+	RunRotateRightAnimation   int            `json:"runRotateRightAnimation"`
+	CrawlAnimation            int            `json:"crawlAnimation"`
+	CrawlRotate180Animation   int            `json:"crawlRotate180Animation"`
+	CrawlRotateLeftAnimation  int            `json:"crawlRotateLeftAnimation"`
+	CrawlRotateRightAnimation int            `json:"crawlRotateRightAnimation"`
+	Actions                   string         `json:"actions"`
+	IsMinimapVisible          bool           `json:"isMinimapVisible"`
+	CombatLevel               int            `json:"combatLevel"`
+	WidthScale                int            `json:"widthScale"`
+	HeightScale               int            `json:"heightScale"`
+	HasRenderPriority         bool           `json:"hasRenderPriority"`
+	Ambient                   int            `json:"ambient"`
+	Contrast                  int            `json:"contrast"`
+	HeadIconSpriteIndex       string         `json:"headIconSpriteIndex"`
+	HeadIconArchiveIDs        string         `json:"headIconArchiveIds"`
+	RotationSpeed             int            `json:"rotationSpeed"`
+	VarbitID                  int            `json:"varbitId"`
+	VarpIndex                 int            `json:"varpIndex"`
+	IsInteractable            bool           `json:"isInteractable"`
+	RotationFlag              bool           `json:"rotationFlag"`
+	IsPet                     bool           `json:"isPet"`
+	Configs                   string         `json:"configs"`
+	Params                    string         `json:"params"`
+	Category                  int            `json:"category"`
+	RecolorToFind             string         `json:"recolorToFind"`
+	RecolorToReplace          string         `json:"recolorToReplace"`
+	RetextureToFind           string         `json:"retextureToFind"`
+	RetextureToReplace        string         `json:"retextureToReplace"`
+	IsFollower                bool           `json:"isFollower"`
+	LowPriorityFollowerOps    bool           `json:"lowPriorityFollowerOps"`
+	RecolorToFindRGB          []string       `json:"recolorToFindRgb,omitempty"`
+	RecolorToReplaceRGB       []string       `json:"recolorToReplaceRgb,omitempty"`
+	Expanded                  map[string]any `json:"expanded,omitempty"`
 }
 
 type ObjectEntry struct {
-	ID                         int    `json:"id"`
-	Name                       string `json:"name"`
-	DecorDisplacement          int    `json:"decorDisplacement"`
-	IsHollow                   bool   `json:"isHollow"`
-	ObjectModels               string `json:"objectModels"`
-	ObjectTypes                string `json:"objectTypes"`
-	MapAreaID                  int    `json:"mapAreaId"`
-	SizeX                      int    `json:"sizeX"`
-	SizeY                      int    `json:"sizeY"`
-	OffsetX                    int    `json:"offsetX"`
-	OffsetY                    int    `json:"offsetY"`
-	OffsetHeight               int    `json:"offsetHeight"`
-	MergeNormals               bool   `json:"mergeNormals"`
-	WallOrDoor                 int    `json:"wallOrDoor"`
-	AnimationID                int    `json:"animationID"`
-	VarbitID                   int    `json:"varbitID"`
-	Ambient                    int    `json:"ambient"`
-	Contrast                   int    `json:"contrast"`
-	RecolorToFind              string `json:"recolorToFind"`
-	RecolorToReplace           string `json:"recolorToReplace"`
-	RetextureToFind            string `json:"retextureToFind"`
-	TextureToReplace           string `json:"textureToReplace"`
-	Actions                    string `json:"actions"`
-	InteractType               int    `json:"interactType"`
-	MapSceneID                 int    `json:"mapSceneID"`
-	BlockingMask               int    `json:"blockingMask"`
-	Shadow                     bool   `json:"shadow"`
-	ModelSizeX                 int    `json:"modelSizeX"`
-	ModelSizeY                 int    `json:"modelSizeY"`
-	ModelSizeHeight            int    `json:"modelSizeHeight"`
-	ObjectID                   int    `json:"objectID"`
-	ObstructsGround            bool   `json:"obstructsGround"`
-	ContouredGround            int    `json:"contouredGround"`
-	SupportsItems              int    `json:"supportsItems"`
-	ConfigChangeDest           string `json:"configChangeDest"`
-	Category                   int    `json:"category"`
-	IsRotated                  bool   `json:"isRotated"`
-	VarpID                     int    `json:"varpID"`
-	AmbientSoundID             int    `json:"ambientSoundId"`
-	AmbientSoundIDs            string `json:"ambientSoundIds"`
-	AmbientSoundRetain         int    `json:"ambientSoundRetain"`
-	AmbientSoundDistance       int    `json:"ambientSoundDistance"`
-	AmbientSoundChangeTicksMin int    `json:"ambientSoundChangeTicksMin"`
-	AmbientSoundChangeTicksMax int    `json:"ambientSoundChangeTicksMax"`
-	Params                     string `json:"params"`
-	ABool2111                  bool   `json:"aBool2111"`
-	BlocksProjectile           bool   `json:"blocksProjectile"`
-	RandomizeAnimStart         bool   `json:"randomizeAnimStart"`
+	ID                         int            `json:"id"`
+	Name                       string         `json:"name"`
+	DecorDisplacement          int            `json:"decorDisplacement"`
+	IsHollow                   bool           `json:"isHollow"`
+	ObjectModels               string         `json:"objectModels"`
+	ObjectTypes                string         `json:"objectTypes"`
+	MapAreaID                  int            `json:"mapAreaId"`
+	SizeX                      int            `json:"sizeX"`
+	SizeY                      int            `json:"sizeY"`
+	OffsetX                    int            `json:"offsetX"`
+	OffsetY                    int            `json:"offsetY"`
+	OffsetHeight               int            `json:"offsetHeight"`
+	MergeNormals               bool           `json:"mergeNormals"`
+	WallOrDoor                 int            `json:"wallOrDoor"`
+	AnimationID                int            `json:"animationID"`
+	VarbitID                   int            `json:"varbitID"`
+	Ambient                    int            `json:"ambient"`
+	Contrast                   int            `json:"contrast"`
+	RecolorToFind              string         `json:"recolorToFind"`
+	RecolorToReplace           string         `json:"recolorToReplace"`
+	RetextureToFind            string         `json:"retextureToFind"`
+	TextureToReplace           string         `json:"textureToReplace"`
+	Actions                    string         `json:"actions"`
+	InteractType               int            `json:"interactType"`
+	MapSceneID                 int            `json:"mapSceneID"`
+	BlockingMask               int            `json:"blockingMask"`
+	Shadow                     bool           `json:"shadow"`
+	ModelSizeX                 int            `json:"modelSizeX"`
+	ModelSizeY                 int            `json:"modelSizeY"`
+	ModelSizeHeight            int            `json:"modelSizeHeight"`
+	ObjectID                   int            `json:"objectID"`
+	ObstructsGround            bool           `json:"obstructsGround"`
+	ContouredGround            int            `json:"contouredGround"`
+	SupportsItems              int            `json:"supportsItems"`
+	ConfigChangeDest           string         `json:"configChangeDest"`
+	Category                   int            `json:"category"`
+	IsRotated                  bool           `json:"isRotated"`
+	VarpID                     int            `json:"varpID"`
+	AmbientSoundID             int            `json:"ambientSoundId"`
+	AmbientSoundIDs            string         `json:"ambientSoundIds"`
+	AmbientSoundRetain         int            `json:"ambientSoundRetain"`
+	AmbientSoundDistance       int            `json:"ambientSoundDistance"`
+	AmbientSoundChangeTicksMin int            `json:"ambientSoundChangeTicksMin"`
+	AmbientSoundChangeTicksMax int            `json:"ambientSoundChangeTicksMax"`
+	Params                     string         `json:"params"`
+	ABool2111                  bool           `json:"aBool2111"`
+	BlocksProjectile           bool           `json:"blocksProjectile"`
+	RandomizeAnimStart         bool           `json:"randomizeAnimStart"`
+	RecolorToFindRGB           []string       `json:"recolorToFindRgb,omitempty"`
+	RecolorToReplaceRGB        []string       `json:"recolorToReplaceRgb,omitempty"`
+	MapAreaSpriteID            *int           `json:"mapAreaSpriteId,omitempty"`
+	MapAreaName                string         `json:"mapAreaName,omitempty"`
+	Expanded                   map[string]any `json:"expanded,omitempty"`
+}
+
+type EquipmentBonuses struct {
+	StabAttack     int `json:"stabAttack"`
+	SlashAttack    int `json:"slashAttack"`
+	CrushAttack    int `json:"crushAttack"`
+	MagicAttack    int `json:"magicAttack"`
+	RangedAttack   int `json:"rangedAttack"`
+	StabDefence    int `json:"stabDefence"`
+	SlashDefence   int `json:"slashDefence"`
+	CrushDefence   int `json:"crushDefence"`
+	MagicDefence   int `json:"magicDefence"`
+	RangedDefence  int `json:"rangedDefence"`
+	MeleeStrength  int `json:"meleeStrength"`
+	RangedStrength int `json:"rangedStrength"`
+	MagicDamage    int `json:"magicDamage"`
+	Prayer         int `json:"prayer"`
+}
+
+type EquipmentStatsEntry struct {
+	ItemID    int    `json:"itemId"`
+	Name      string `json:"name"`
+	EquipSlot int    `json:"equipSlot"`
+	SlotName  string `json:"slotName"`
+	EquipmentBonuses
+	AttackSpeed  int             `json:"attackSpeed"`
+	Requirements json.RawMessage `json:"requirements"`
+}
+
+type LoadoutRequest struct {
+	Slots map[string]int `json:"slots"`
+}
+
+type LoadoutItem struct {
+	Slot     int    `json:"slot"`
+	SlotName string `json:"slotName"`
+	ItemID   int    `json:"itemId"`
+	Name     string `json:"name"`
+	Members  bool   `json:"members"`
+	WearPos2 int    `json:"wearPos2"`
+	WearPos3 int    `json:"wearPos3"`
+}
+
+type LoadoutEntry struct {
+	Items       []LoadoutItem    `json:"items"`
+	Bonuses     EquipmentBonuses `json:"bonuses"`
+	AttackSpeed int              `json:"attackSpeed"`
+	Members     bool             `json:"members"`
+	Valid       bool             `json:"valid"`
+	Conflicts   []string         `json:"conflicts"`
+}
+
+type MorphTarget struct {
+	Value   int    `json:"value"`
+	Default bool   `json:"default"`
+	ID      int    `json:"id"`
+	Name    string `json:"name,omitempty"`
+}
+
+type MorphEntry struct {
+	ID       int `json:"id"`
+	VarbitID int `json:"varbitId"`
+	VarpID   int `json:"varpId"`
+	Value    int `json:"value"`
+	TargetID int `json:"targetId"`
+	Target   any `json:"target"`
+}
+
+type MorphListEntry struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	VarbitID int           `json:"varbitId"`
+	VarpID   int           `json:"varpId"`
+	Morphs   []MorphTarget `json:"morphs"`
+}
+
+type VarDependentEntry struct {
+	EntityType string        `json:"entityType"`
+	ID         int           `json:"id"`
+	Name       string        `json:"name"`
+	Morphs     []MorphTarget `json:"morphs"`
+}
+
+type UsageEntry struct {
+	EntityType string `json:"entityType"`
+	EntityID   int    `json:"entityId"`
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+}
+
+type EnumValueEntry struct {
+	Key       int64  `json:"key"`
+	KeyName   string `json:"keyName,omitempty"`
+	Value     any    `json:"value"`
+	ValueName string `json:"valueName,omitempty"`
+}
+
+type EnumDefEntry struct {
+	ID      int              `json:"id"`
+	KeyType string           `json:"keyType"`
+	ValType string           `json:"valType"`
+	Default any              `json:"default"`
+	Size    int              `json:"size"`
+	Entries []EnumValueEntry `json:"entries"`
+}
+
+type EnumLookupEntry struct {
+	EnumID int `json:"enumId"`
+	EnumValueEntry
+	IsDefault bool `json:"isDefault"`
+}
+
+type ParamEntry struct {
+	ID        int    `json:"id"`
+	Type      string `json:"type,omitempty"`
+	IsMembers bool   `json:"isMembers"`
+	Value     any    `json:"value"`
+	ValueName string `json:"valueName,omitempty"`
+}
+
+type StructReferenceEntry struct {
+	EnumID int `json:"enumId"`
+	Key    int `json:"key"`
+}
+
+type StructDefEntry struct {
+	ID     int                    `json:"id"`
+	Params []ParamEntry           `json:"params"`
+	Enums  []StructReferenceEntry `json:"enums"`
+}
+
+type DBColumnEntry struct {
+	ID       int             `json:"id"`
+	Name     string          `json:"name,omitempty"`
+	Types    []string        `json:"types"`
+	Defaults json.RawMessage `json:"defaults"`
+}
+
+type DBTableDefEntry struct {
+	ID          int             `json:"id"`
+	ColumnCount int             `json:"columnCount"`
+	RowCount    int             `json:"rowCount"`
+	Columns     []DBColumnEntry `json:"columns,omitempty"`
+}
+
+type DBRowColumnEntry struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name,omitempty"`
+	Types  []string `json:"types"`
+	Values [][]any  `json:"values"`
+}
+
+type DBRowDefEntry struct {
+	ID      int                `json:"id"`
+	TableID int                `json:"tableId"`
+	Columns []DBRowColumnEntry `json:"columns"`
+}
+
+type VarbitDefEntry struct {
+	ID                  int  `json:"id"`
+	BaseVar             int  `json:"baseVar"`
+	LeastSignificantBit int  `json:"leastSignificantBit"`
+	MostSignificantBit  int  `json:"mostSignificantBit"`
+	BitCount            int  `json:"bitCount"`
+	Value               *int `json:"value,omitempty"`
+}
+
+type SequenceFrameEntry struct {
+	Index       int `json:"index"`
+	FrameID     int `json:"frameId"`
+	Length      int `json:"length"`
+	ChatFrameID int `json:"chatFrameId"`
+}
+
+type SequenceDefEntry struct {
+	ID                  int                  `json:"id"`
+	FrameCount          int                  `json:"frameCount"`
+	FrameStep           int                  `json:"frameStep"`
+	InterleaveLeft      json.RawMessage      `json:"interleaveLeft"`
+	Stretches           bool                 `json:"stretches"`
+	ForcedPriority      int                  `json:"forcedPriority"`
+	LeftHandItem        int                  `json:"leftHandItem"`
+	RightHandItem       int                  `json:"rightHandItem"`
+	MaxLoops            int                  `json:"maxLoops"`
+	PrecedenceAnimating int                  `json:"precedenceAnimating"`
+	Priority            int                  `json:"priority"`
+	ReplyMode           int                  `json:"replyMode"`
+	AnimMayaID          int                  `json:"animMayaId"`
+	AnimMayaStart       int                  `json:"animMayaStart"`
+	AnimMayaEnd         int                  `json:"animMayaEnd"`
+	Sounds              json.RawMessage      `json:"sounds"`
+	DurationClientTicks int                  `json:"durationClientTicks"`
+	DurationGameTicks   float64              `json:"durationGameTicks"`
+	DurationMillis      int                  `json:"durationMillis"`
+	Frames              []SequenceFrameEntry `json:"frames"`
+}
+
+type SpotAnimEntry struct {
+	ID                  int      `json:"id"`
+	ModelID             int      `json:"modelId"`
+	AnimationID         int      `json:"animationId"`
+	ResizeX             int      `json:"resizeX"`
+	ResizeY             int      `json:"resizeY"`
+	Rotation            int      `json:"rotation"`
+	Ambient             int      `json:"ambient"`
+	Contrast            int      `json:"contrast"`
+	RecolorToFind       string   `json:"recolorToFind"`
+	RecolorToReplace    string   `json:"recolorToReplace"`
+	TextureToFind       string   `json:"textureToFind"`
+	TextureToReplace    string   `json:"textureToReplace"`
+	RecolorToFindRGB    []string `json:"recolorToFindRgb,omitempty"`
+	RecolorToReplaceRGB []string `json:"recolorToReplaceRgb,omitempty"`
+}
+
+type KitDefEntry struct {
+	ID                  int             `json:"id"`
+	BodyPartID          int             `json:"bodyPartId"`
+	BodyPart            string          `json:"bodyPart"`
+	Gender              string          `json:"gender"`
+	Models              json.RawMessage `json:"models"`
+	ChatheadModels      json.RawMessage `json:"chatheadModels"`
+	RecolorToFind       json.RawMessage `json:"recolorToFind"`
+	RecolorToReplace    json.RawMessage `json:"recolorToReplace"`
+	RetextureToFind     json.RawMessage `json:"retextureToFind"`
+	RetextureToReplace  json.RawMessage `json:"retextureToReplace"`
+	NonSelectable       bool            `json:"nonSelectable"`
+	RecolorToFindRGB    []string        `json:"recolorToFindRgb,omitempty"`
+	RecolorToReplaceRGB []string        `json:"recolorToReplaceRgb,omitempty"`
+}
+
+type InventoryStockEntry struct {
+	Slot   int    `json:"slot"`
+	ItemID int    `json:"itemId"`
+	Name   string `json:"name"`
+	Count  int    `json:"count"`
+}
+
+type InventoryDefEntry struct {
+	ID    int                   `json:"id"`
+	Size  int                   `json:"size"`
+	Stock []InventoryStockEntry `json:"stock"`
+}
+
+type ItemInventoryEntry struct {
+	InventoryID int `json:"inventoryId"`
+	Size        int `json:"size"`
+	Slot        int `json:"slot"`
+	Count       int `json:"count"`
+}
+
+type HSLColor struct {
+	Hue        float64 `json:"hue"`
+	Saturation float64 `json:"saturation"`
+	Lightness  float64 `json:"lightness"`
+}
+
+type UnderlayDefEntry struct {
+	ID       int      `json:"id"`
+	Color    int      `json:"color"`
+	ColorHex string   `json:"colorHex"`
+	ColorHSL HSLColor `json:"colorHsl"`
+}
+
+type OverlayDefEntry struct {
+	ID                int       `json:"id"`
+	Color             int       `json:"color"`
+	ColorHex          string    `json:"colorHex"`
+	ColorHSL          HSLColor  `json:"colorHsl"`
+	Texture           int       `json:"texture"`
+	SecondaryColor    int       `json:"secondaryColor"`
+	SecondaryColorHex string    `json:"secondaryColorHex,omitempty"`
+	SecondaryColorHSL *HSLColor `json:"secondaryColorHsl,omitempty"`
+	HideUnderlay      bool      `json:"hideUnderlay"`
+}
+
+type HitsplatDefEntry struct {
+	ID               int    `json:"id"`
+	StringFormat     string `json:"stringFormat"`
+	FontType         int    `json:"fontType"`
+	TextColor        int    `json:"textColor"`
+	TextColorHex     string `json:"textColorHex"`
+	TextOffsetY      int    `json:"textOffsetY"`
+	LeftSprite       int    `json:"leftSprite"`
+	LeftSprite2      int    `json:"leftSprite2"`
+	RightSpriteID    int    `json:"rightSpriteId"`
+	BackgroundSprite int    `json:"backgroundSprite"`
+	UseDamage        int    `json:"useDamage"`
+	DisplayCycles    int    `json:"displayCycles"`
+	FadeStartCycle   int    `json:"fadeStartCycle"`
+	ScrollToOffsetX  int    `json:"scrollToOffsetX"`
+	ScrollToOffsetY  int    `json:"scrollToOffsetY"`
+	VarbitID         int    `json:"varbitID"`
+	VarpID           int    `json:"varpID"`
+	MultiHitsplats   string `json:"multihitsplats"`
+}
+
+type HealthBarDefEntry struct {
+	ID            int `json:"id"`
+	FrontSpriteID int `json:"frontSpriteId"`
+	BackSpriteID  int `json:"backSpriteId"`
+	HealthScale   int `json:"healthScale"`
+	Padding       int `json:"padding"`
+	Field3276     int `json:"field3276"`
+	Field3277     int `json:"field3277"`
+	Field3278     int `json:"field3278"`
+	Field3283     int `json:"field3283"`
+	Field3272     int `json:"field3272"`
+	Field3275     int `json:"field3275"`
+}
+
+type AreaDefEntry struct {
+	ID           int             `json:"id"`
+	Name         string          `json:"name"`
+	SpriteID     int             `json:"spriteId"`
+	TextColor    int             `json:"textColor"`
+	TextColorHex string          `json:"textColorHex"`
+	Options      json.RawMessage `json:"options"`
+	Category     int             `json:"category"`
+}
+
+type InterfaceComponentEntry struct {
+	ID          int                        `json:"id"`
+	Group       int                        `json:"group"`
+	Component   int                        `json:"component"`
+	ParentID    int                        `json:"parentId"`
+	IsIf3       bool                       `json:"isIf3"`
+	Type        int                        `json:"type"`
+	TypeName    string                     `json:"typeName"`
+	ContentType int                        `json:"contentType"`
+	X           int                        `json:"x"`
+	Y           int                        `json:"y"`
+	Width       int                        `json:"width"`
+	Height      int                        `json:"height"`
+	IsHidden    bool                       `json:"isHidden"`
+	SpriteID    int                        `json:"spriteId"`
+	ModelType   int                        `json:"modelType"`
+	ModelID     int                        `json:"modelId"`
+	FontID      int                        `json:"fontId"`
+	Text        string                     `json:"text"`
+	TextColor   int                        `json:"textColor"`
+	Name        string                     `json:"name"`
+	Actions     json.RawMessage            `json:"actions"`
+	ItemIDs     json.RawMessage            `json:"itemIds"`
+	Listeners   json.RawMessage            `json:"listeners"`
+	Children    []*InterfaceComponentEntry `json:"children,omitempty"`
+}
+
+type InterfaceTreeEntry struct {
+	Group          int                        `json:"group"`
+	ComponentCount int                        `json:"componentCount"`
+	Components     []*InterfaceComponentEntry `json:"components"`
+}
+
+type ScriptRefEntry struct {
+	Type             string `json:"type"`
+	ID               int    `json:"id"`
+	InstructionIndex int    `json:"instructionIndex"`
+}
+
+type ScriptSummaryEntry struct {
+	ID               int `json:"id"`
+	IntArgCount      int `json:"intArgCount"`
+	StringArgCount   int `json:"stringArgCount"`
+	InstructionCount int `json:"instructionCount"`
+}
+
+type ScriptDefEntry struct {
+	ID               int              `json:"id"`
+	IntArgCount      int              `json:"intArgCount"`
+	StringArgCount   int              `json:"stringArgCount"`
+	LocalIntCount    int              `json:"localIntCount"`
+	LocalStringCount int              `json:"localStringCount"`
+	InstructionCount int              `json:"instructionCount"`
+	Switches         json.RawMessage  `json:"switches"`
+	References       []ScriptRefEntry `json:"references"`
+	Disassembly      string           `json:"disassembly"`
+}
+
+type SpriteFrameEntry struct {
+	SpriteID  int `json:"spriteId"`
+	Frame     int `json:"frame"`
+	OffsetX   int `json:"offsetX"`
+	OffsetY   int `json:"offsetY"`
+	Width     int `json:"width"`
+	Height    int `json:"height"`
+	MaxWidth  int `json:"maxWidth"`
+	MaxHeight int `json:"maxHeight"`
+}
+
+type TextureDefEntry struct {
+	ID                 int             `json:"id"`
+	SpriteIDs          json.RawMessage `json:"spriteIds"`
+	AverageRGB         int             `json:"averageRgb"`
+	AverageColorHex    string          `json:"averageColorHex"`
+	AnimationDirection int             `json:"animationDirection"`
+	AnimationSpeed     int             `json:"animationSpeed"`
 }
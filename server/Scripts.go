@@ -0,0 +1,148 @@
+/* Scripts.go
+2024, cdfisher
+----------------
+Handlers for ClientScript (CS2) definitions, returning a script's disassembly and searching
+scripts by the opcodes, vars, scripts, enums, and constants they reference.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Query params for searching by each reference type recorded in script_references, other than opcodes
+var ScriptRefParams = []string{"constant", "varp", "varbit", "varc_int", "varc_string", "script", "enum"}
+
+// parseOpcode accepts an opcode number, a name such as get_varbit, or op_<number> for unnamed opcodes
+func parseOpcode(val string, c *gin.Context) (int, bool) {
+	if opcode, err := strconv.Atoi(strings.TrimPrefix(val, "op_")); err == nil {
+		return opcode, true
+	}
+
+	var opcode int
+	row := db.QueryRowContext(c, "SELECT opcode FROM script_opcodes WHERE name == ?", val)
+	if err := row.Scan(&opcode); err != nil {
+		if err != sql.ErrNoRows {
+			fmt.Println(err)
+		}
+		return 0, false
+	}
+	return opcode, true
+}
+
+func fetchScriptReferences(scriptID int, c *gin.Context) []ScriptRefEntry {
+	output := []ScriptRefEntry{}
+
+	query := "SELECT ref_type, ref_id, instruction_index FROM script_references WHERE script_id == ? AND ref_type NOT IN ('opcode', 'constant') ORDER BY ref_type, ref_id, instruction_index"
+	dbRows, err := db.QueryContext(c, query, scriptID)
+	if err != nil {
+		log.Fatal("Error encountered executing reference query for script ", scriptID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := ScriptRefEntry{}
+		err = dbRows.Scan(&rowData.Type, &rowData.ID, &rowData.InstructionIndex)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+// GetScript returns a script with its disassembly, or just the disassembly as plain text with format=text
+func GetScript(c *gin.Context) {
+	script := ScriptDefEntry{}
+	var switches string
+
+	query := "SELECT id, int_arg_count, string_arg_count, local_int_count, local_string_count, instruction_count, switches, disassembly FROM scripts WHERE id == ?"
+	row := db.QueryRowContext(c, query, idParam(c, "id"))
+	err := row.Scan(&script.ID, &script.IntArgCount, &script.StringArgCount, &script.LocalIntCount,
+		&script.LocalStringCount, &script.InstructionCount, &switches, &script.Disassembly)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No script with id " + c.Param("id") + " was found"})
+		return
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for script ", c.Param("id"), " : ", err)
+	}
+
+	if c.Query("format") == "text" {
+		c.String(http.StatusOK, script.Disassembly)
+		return
+	}
+	script.Switches = json.RawMessage(switches)
+	script.References = fetchScriptReferences(script.ID, c)
+
+	c.JSON(http.StatusOK, script)
+}
+
+// SearchScripts returns every script matching all of the opcode and reference query params given,
+// e.g. ?enum=1234 or ?opcode=get_varbit&varbit=4567
+func SearchScripts(c *gin.Context) {
+	var conditions []string
+	var args []any
+	refCondition := "EXISTS (SELECT 1 FROM script_references r WHERE r.script_id = s.id AND r.ref_type == ? AND r.ref_id == ?)"
+
+	if val, ok := c.GetQuery("opcode"); ok {
+		opcode, ok := parseOpcode(val, c)
+		if !ok {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Unknown opcode %s", val)})
+			return
+		}
+		conditions = append(conditions, refCondition)
+		args = append(args, "opcode", opcode)
+	}
+
+	for _, refType := range ScriptRefParams {
+		val, ok := c.GetQuery(refType)
+		if !ok {
+			continue
+		}
+		refID, err := strconv.Atoi(val)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid %s %s", refType, val)})
+			return
+		}
+		conditions = append(conditions, refCondition)
+		args = append(args, refType, refID)
+	}
+
+	if len(conditions) == 0 {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf(
+			"At least one of opcode, %s is required", strings.Join(ScriptRefParams, ", "))})
+		return
+	}
+
+	query := fmt.Sprintf("SELECT s.id, s.int_arg_count, s.string_arg_count, s.instruction_count FROM scripts s WHERE %s ORDER BY s.id",
+		strings.Join(conditions, " AND "))
+	dbRows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		log.Fatal("Error encountered executing script search ", args, " : ", err)
+	}
+	defer dbRows.Close()
+
+	var results []ScriptSummaryEntry
+	for dbRows.Next() {
+		rowData := ScriptSummaryEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.IntArgCount, &rowData.StringArgCount, &rowData.InstructionCount)
+		if err != nil {
+			fmt.Println(err)
+		}
+		results = append(results, rowData)
+	}
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No scripts matching query were found"})
+	}
+}
@@ -0,0 +1,67 @@
+/* Sequences.go
+2024, cdfisher
+----------------
+Handlers for sequence (animation) definitions, including how long they play for.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+const clientTickMillis = 20
+const gameTickMillis = 600
+
+func fetchSequenceFrames(sequenceID int, c *gin.Context) []SequenceFrameEntry {
+	output := []SequenceFrameEntry{}
+
+	query := "SELECT frame_index, frame_id, frame_length, chat_frame_id FROM sequence_frames WHERE sequence_id == ? ORDER BY frame_index"
+	dbRows, err := db.QueryContext(c, query, sequenceID)
+	if err != nil {
+		log.Fatal("Error encountered executing frame query for sequence ", sequenceID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := SequenceFrameEntry{}
+		err = dbRows.Scan(&rowData.Index, &rowData.FrameID, &rowData.Length, &rowData.ChatFrameID)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func GetSequence(c *gin.Context) {
+	sequenceID := idParam(c, "id")
+
+	sequence := SequenceDefEntry{}
+	var interleaveLeft, sounds string
+	query := "SELECT id, frame_count, frame_step, interleave_left, stretches, forced_priority, left_hand_item, right_hand_item, max_loops, precedence_animating, priority, reply_mode, anim_maya_id, anim_maya_start, anim_maya_end, sounds, duration_client_ticks FROM sequences WHERE id == ?"
+	row := db.QueryRowContext(c, query, sequenceID)
+	err := row.Scan(&sequence.ID, &sequence.FrameCount, &sequence.FrameStep, &interleaveLeft, &sequence.Stretches,
+		&sequence.ForcedPriority, &sequence.LeftHandItem, &sequence.RightHandItem, &sequence.MaxLoops,
+		&sequence.PrecedenceAnimating, &sequence.Priority, &sequence.ReplyMode, &sequence.AnimMayaID,
+		&sequence.AnimMayaStart, &sequence.AnimMayaEnd, &sounds, &sequence.DurationClientTicks)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No sequence with id " + c.Param("id") + " was found"})
+		return
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for sequence ", sequenceID, " : ", err)
+	}
+
+	sequence.InterleaveLeft = json.RawMessage(interleaveLeft)
+	sequence.Sounds = json.RawMessage(sounds)
+	sequence.DurationMillis = sequence.DurationClientTicks * clientTickMillis
+	sequence.DurationGameTicks = float64(sequence.DurationMillis) / gameTickMillis
+	sequence.Frames = fetchSequenceFrames(sequence.ID, c)
+
+	c.JSON(http.StatusOK, sequence)
+}
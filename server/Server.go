@@ -16,6 +16,8 @@ import (
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 var db *sql.DB
@@ -26,12 +28,32 @@ func notFound(c *gin.Context, key string, route string) {
 		route)})
 }
 
-func fetchItems(query string, itemID string, c *gin.Context) []ItemEntry {
+// buildKeyQuery returns the query for searching a table by a key with an exact (1) or fuzzy (2) match, or an empty
+// string after responding with 404 if the key is unknown
+func buildKeyQuery(queryTypes map[string]int, table string, key string, c *gin.Context) string {
+	queryType, ok := queryTypes[key]
+	if !ok {
+		notFound(c, key, "/"+table)
+		return ""
+	}
+	return fmt.Sprintf(Queries[queryType], table, key)
+}
+
+// idParam reads a numeric id from the given path param, returning -1 (which no definition uses) if it isn't numeric
+func idParam(c *gin.Context, name string) int {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		return -1
+	}
+	return id
+}
+
+func fetchItems(query string, args []any, c *gin.Context) []ItemEntry {
 	var output []ItemEntry
 
-	dbRows, err := db.QueryContext(c, query, itemID)
+	dbRows, err := db.QueryContext(c, query, args...)
 	if err != nil {
-		log.Fatal("Error encountered executing query for search ", itemID, " : ", err)
+		log.Fatal("Error encountered executing query for search ", args, " : ", err)
 	}
 
 	i := 0
@@ -49,16 +71,37 @@ func fetchItems(query string, itemID string, c *gin.Context) []ItemEntry {
 			&rowData.Team, &rowData.Weight, &rowData.ShiftClickDropIndex, &rowData.BoughtID, &rowData.BoughtTemplateID,
 			&rowData.PlaceholderID, &rowData.PlaceholderTemplateID, &rowData.ColorFind, &rowData.ColorReplace,
 			&rowData.Params, &rowData.CountCo, &rowData.CountObj, &rowData.TextureFind, &rowData.TextureReplace,
-			&rowData.Category)
+			&rowData.Category, &rowData.Variant, &rowData.BaseID)
 		if err != nil {
 			fmt.Println(err)
 		}
+		rowData.WearSlot1 = slotName(rowData.WearPos1)
+		rowData.WearSlot2 = slotName(rowData.WearPos2)
+		rowData.WearSlot3 = slotName(rowData.WearPos3)
 		output = append(output, rowData)
 		i++
 	}
 	return output
 }
 
+// Values accepted by the variant query param
+var ItemVariants = map[string]bool{"base": true, "noted": true, "placeholder": true, "bought": true}
+
+// itemVariantQuery restricts an items query to the variant query param, if one is given. Unknown variants are
+// rejected with a 400 and ok set to false.
+func itemVariantQuery(query string, args []any, c *gin.Context) (string, []any, bool) {
+	variant, ok := c.GetQuery("variant")
+	if !ok {
+		return query, args, true
+	}
+	if !ItemVariants[variant] {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Unknown variant %s, expected one of "+
+			"base, noted, placeholder, or bought", variant)})
+		return "", nil, false
+	}
+	return fmt.Sprintf("SELECT * FROM (%s) WHERE variant == ? ORDER BY id", query), append(args, variant), true
+}
+
 func BuildItemQuery(key string, c *gin.Context) string {
 	queryType, ok := ItemQueryTypes[key]
 	if !ok {
@@ -94,10 +137,31 @@ func GetItems(c *gin.Context) {
 	searchKey := c.Param("key")
 	searchVal := c.Param("value")
 
+	expandFields, expandDepth, ok := parseExpand(c, ItemExpandFields)
+	if !ok {
+		return
+	}
+
+	// Allow slot names for wear positions, e.g. /items/wear_pos_2/hair
+	if strings.HasPrefix(searchKey, "wear_pos_") {
+		if slot, ok := parseEquipmentSlot(searchVal); ok {
+			searchVal = strconv.Itoa(slot)
+		}
+	}
+
 	var results []ItemEntry
 
 	queryString := BuildItemQuery(searchKey, c)
-	results = append(results, fetchItems(queryString, searchVal, c)...)
+	if queryString == "" {
+		return
+	}
+	queryString, args, ok := itemVariantQuery(queryString, []any{searchVal}, c)
+	if !ok {
+		return
+	}
+	results = append(results, fetchItems(queryString, args, c)...)
+	expandItems(results, expandFields, expandDepth, c)
+	decodeItemColors(results, c)
 
 	n := len(results)
 
@@ -177,10 +241,17 @@ func GetNPCs(c *gin.Context) {
 	searchKey := c.Param("key")
 	searchVal := c.Param("value")
 
+	expandFields, expandDepth, ok := parseExpand(c, NPCExpandFields)
+	if !ok {
+		return
+	}
+
 	var results []NPCEntry
 
 	queryString := BuildNPCQuery(searchKey, c)
 	results = append(results, fetchNPCs(queryString, searchVal, c)...)
+	expandNPCs(results, expandFields, expandDepth, c)
+	decodeNPCColors(results, c)
 
 	n := len(results)
 
@@ -259,10 +330,22 @@ func GetObjects(c *gin.Context) {
 	searchKey := c.Param("key")
 	searchVal := c.Param("value")
 
+	expandFields, expandDepth, ok := parseExpand(c, ObjectExpandFields)
+	if !ok {
+		return
+	}
+	annotations, ok := parseAnnotate(c, ObjectAnnotations)
+	if !ok {
+		return
+	}
+
 	var results []ObjectEntry
 
 	queryString := BuildObjectQuery(searchKey, c)
 	results = append(results, fetchObjects(queryString, searchVal, c)...)
+	expandObjects(results, expandFields, expandDepth, c)
+	annotateObjects(results, annotations, c)
+	decodeObjectColors(results, c)
 
 	n := len(results)
 
@@ -280,6 +363,53 @@ func initializeRouter() *gin.Engine {
 	r.GET("items/:key/:value", GetItems)
 	r.GET("npcs/:key/:value", GetNPCs)
 	r.GET("objects/:key/:value", GetObjects)
+	r.GET("spotanims/:key/:value", GetSpotAnims)
+	r.GET("hitsplats/:key/:value", GetHitsplats)
+	r.GET("healthbars/:key/:value", GetHealthBars)
+	r.GET("items/recolor", SearchItemColors)
+	r.GET("npcs/recolor", SearchNPCColors)
+	r.GET("objects/recolor", SearchObjectColors)
+	r.GET("items/by_id/:id/equipment", GetItemEquipment)
+	r.GET("items/by_id/:id/inventories", GetItemInventories)
+	r.GET("items/equipment/:slot", GetItemsBySlot)
+	r.GET("items/hides/:slot", GetItemsHidingSlot)
+	r.GET("equipment", GetEquipment)
+	r.POST("equipment/loadout", PostLoadout)
+	r.GET("npcs/:key/morph", GetNPCMorph)
+	r.GET("npcs/:key/morphs", GetNPCMorphs)
+	r.GET("objects/:key/morph", GetObjectMorph)
+	r.GET("objects/:key/morphs", GetObjectMorphs)
+	r.GET("varbits/:id", GetVarbit)
+	r.GET("varbits/:id/dependents", GetVarbitDependents)
+	r.GET("varps/:id/varbits", GetVarpVarbits)
+	r.GET("varps/:id/dependents", GetVarpDependents)
+	r.GET("models/:id/usages", GetModelUsages)
+	r.GET("animations/:id/usages", GetAnimationUsages)
+	r.GET("enums/:id", GetEnum)
+	r.GET("enums/:id/lookup", GetEnumLookup)
+	r.GET("structs/:id", GetStruct)
+	r.GET("dbtables", GetDBTables)
+	r.GET("dbtables/:id", GetDBTable)
+	r.GET("dbtables/:id/rows", GetDBTableRows)
+	r.GET("dbrows/:id", GetDBRow)
+	r.GET("sequences/:id", GetSequence)
+	r.GET("kits", GetKits)
+	r.GET("kits/:id", GetKit)
+	r.GET("inventories/:id", GetInventory)
+	r.GET("underlays", GetUnderlays)
+	r.GET("underlays/:id", GetUnderlay)
+	r.GET("overlays", GetOverlays)
+	r.GET("overlays/:id", GetOverlay)
+	r.GET("areas/:id", GetArea)
+	r.GET("areas/:id/objects", GetAreaObjects)
+	r.GET("interfaces/search", SearchInterfaces)
+	r.GET("interfaces/:group", GetInterface)
+	r.GET("scripts/search", SearchScripts)
+	r.GET("scripts/:id", GetScript)
+	r.GET("sprites/:id", GetSprite)
+	r.GET("sprites/:id/:frame", GetSpriteFrame)
+	r.GET("textures/:id", GetTexture)
+	r.GET("textures/:id/usages", GetTextureUsages)
 	return r
 }
 
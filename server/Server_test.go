@@ -0,0 +1,97 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/ncruces/go-sqlite3/vfs/memdb"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// openTestDB points the server at an in-memory DB created from schema.sql and seeded with the given statements
+func openTestDB(t *testing.T, statements ...string) {
+	t.Helper()
+	schema, err := os.ReadFile("../schema.sql")
+	if err != nil {
+		t.Fatal("Error reading schema file: ", err)
+	}
+
+	// Shared by name so every connection in the pool sees the same DB
+	name := strings.ReplaceAll(t.Name(), "/", "_")
+	db, err = sql.Open("sqlite3", "file:/"+name+"?vfs=memdb")
+	if err != nil {
+		t.Fatal("Error opening test DB: ", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		memdb.Delete(name)
+	})
+
+	for _, statement := range append([]string{string(schema)}, statements...) {
+		if _, err = db.Exec(statement); err != nil {
+			t.Fatalf("Error seeding test DB with %.80q : %s", statement, err)
+		}
+	}
+}
+
+// serve runs a request through the router and returns the recorded response
+func serve(method string, url string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	initializeRouter().ServeHTTP(recorder, httptest.NewRequest(method, url, strings.NewReader(body)))
+	return recorder
+}
+
+func TestItemVariantFilter(t *testing.T) {
+	openTestDB(t, "INSERT INTO items (id, name, wear_pos_1, wear_pos_2, variant) VALUES "+
+		"(1163, 'Rune full helm', 0, 8, 'base'), (1164, 'Rune full helm', -1, -1, 'noted'), "+
+		"(4151, 'Abyssal whip', 3, -1, 'base'), (4152, 'Abyssal whip', -1, -1, 'noted'), "+
+		"(20405, 'Abyssal whip', 3, -1, 'placeholder')")
+
+	tests := []struct {
+		url    string
+		status int
+		ids    []int
+	}{
+		{url: "/items/name/whip", status: http.StatusOK, ids: []int{4151, 4152, 20405}},
+		{url: "/items/name/whip?variant=noted", status: http.StatusOK, ids: []int{4152}},
+		{url: "/items/name/whip?variant=bought", status: http.StatusNotFound},
+		{url: "/items/name/whip?variant=notes", status: http.StatusBadRequest},
+		{url: "/items/equipment/weapon?variant=base", status: http.StatusOK, ids: []int{4151}},
+		{url: "/items/equipment/weapon?variant=", status: http.StatusBadRequest},
+		{url: "/items/hides/hair?variant=base", status: http.StatusOK, ids: []int{1163}},
+		{url: "/items/hides/hair?variant=noted", status: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		recorder := serve(http.MethodGet, test.url, "")
+		if recorder.Code != test.status {
+			t.Errorf("%s: got status %d, want %d: %s", test.url, recorder.Code, test.status, recorder.Body)
+			continue
+		}
+		if test.status != http.StatusOK {
+			continue
+		}
+
+		var items []ItemEntry
+		if err := json.Unmarshal(recorder.Body.Bytes(), &items); err != nil {
+			t.Fatalf("%s: %s", test.url, err)
+		}
+		var ids []int
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		if !reflect.DeepEqual(ids, test.ids) {
+			t.Errorf("%s: got items %v, want %v", test.url, ids, test.ids)
+		}
+	}
+}
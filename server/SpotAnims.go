@@ -0,0 +1,63 @@
+/* SpotAnims.go
+2024, cdfisher
+----------------
+Handlers for spot animation (graphics) definitions, searched by key and value like items.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+func fetchSpotAnims(query string, spotAnimID string, c *gin.Context) []SpotAnimEntry {
+	var output []SpotAnimEntry
+	dbRows, err := db.QueryContext(c, query, spotAnimID)
+	if err != nil {
+		log.Fatal("Error encountered executing query for search ", spotAnimID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := SpotAnimEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.ModelID, &rowData.AnimationID, &rowData.ResizeX, &rowData.ResizeY,
+			&rowData.Rotation, &rowData.Ambient, &rowData.Contrast, &rowData.RecolorToFind, &rowData.RecolorToReplace,
+			&rowData.TextureToFind, &rowData.TextureToReplace)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func decodeSpotAnimColors(spotAnims []SpotAnimEntry, c *gin.Context) {
+	if c.Query("colors") != "rgb" {
+		return
+	}
+	for i := range spotAnims {
+		spotAnims[i].RecolorToFindRGB = decodeColors(spotAnims[i].RecolorToFind)
+		spotAnims[i].RecolorToReplaceRGB = decodeColors(spotAnims[i].RecolorToReplace)
+	}
+}
+
+func GetSpotAnims(c *gin.Context) {
+	searchKey := c.Param("key")
+	searchVal := c.Param("value")
+
+	queryString := buildKeyQuery(SpotAnimQueryTypes, "spotanims", searchKey, c)
+	if queryString == "" {
+		return
+	}
+	results := fetchSpotAnims(queryString, searchVal, c)
+	decodeSpotAnimColors(results, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No spotanims matching query were found"})
+	}
+}
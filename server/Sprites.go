@@ -0,0 +1,144 @@
+/* Sprites.go
+2024, cdfisher
+----------------
+Handlers for sprite groups, returning frame metadata and encoding single frames to PNG. Frames
+are sent with an ETag so clients can revalidate them once their cached copy expires.
+
+Pixels are stored as ARGB, with groups dumped without an alpha channel already made opaque.
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Sprites only change when the DB is rebuilt, so clients can hold on to them for a day
+const spriteCacheControl = "public, max-age=86400"
+
+func fetchSpriteFrames(spriteID int, c *gin.Context) []SpriteFrameEntry {
+	var output []SpriteFrameEntry
+
+	query := "SELECT sprite_id, frame, offset_x, offset_y, width, height, max_width, max_height FROM sprites WHERE sprite_id == ? ORDER BY frame"
+	dbRows, err := db.QueryContext(c, query, spriteID)
+	if err != nil {
+		log.Fatal("Error encountered executing query for sprite ", spriteID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := SpriteFrameEntry{}
+		err = dbRows.Scan(&rowData.SpriteID, &rowData.Frame, &rowData.OffsetX, &rowData.OffsetY, &rowData.Width,
+			&rowData.Height, &rowData.MaxWidth, &rowData.MaxHeight)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+// spriteImage draws a frame's pixels, either on a canvas of just the frame or, if padded, at its offset on a
+// canvas of the group's full size
+func spriteImage(frame SpriteFrameEntry, pixels []byte, padded bool) *image.NRGBA {
+	originX, originY := 0, 0
+	bounds := image.Rect(0, 0, frame.Width, frame.Height)
+	if padded {
+		originX, originY = frame.OffsetX, frame.OffsetY
+		bounds = image.Rect(0, 0, max(frame.MaxWidth, frame.OffsetX+frame.Width),
+			max(frame.MaxHeight, frame.OffsetY+frame.Height))
+	}
+
+	img := image.NewNRGBA(bounds)
+	for i := 0; i < frame.Width*frame.Height && 4*i+4 <= len(pixels); i++ {
+		argb := binary.BigEndian.Uint32(pixels[4*i:])
+		img.SetNRGBA(originX+i%frame.Width, originY+i/frame.Width,
+			color.NRGBA{R: uint8(argb >> 16), G: uint8(argb >> 8), B: uint8(argb), A: uint8(argb >> 24)})
+	}
+	return img
+}
+
+// spriteETag identifies one rendering of a frame, changing if the DB is rebuilt with a different frame
+func spriteETag(frame SpriteFrameEntry, pixels []byte, padded bool) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d %d %d %d %d %d %d %d %t ", frame.SpriteID, frame.Frame, frame.OffsetX, frame.OffsetY,
+		frame.Width, frame.Height, frame.MaxWidth, frame.MaxHeight, padded)
+	hash.Write(pixels)
+	return fmt.Sprintf(`"%x"`, hash.Sum(nil)[:16])
+}
+
+// etagMatches reports whether an If-None-Match header lists an ETag
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func GetSprite(c *gin.Context) {
+	results := fetchSpriteFrames(idParam(c, "id"), c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No sprite with id " + c.Param("id") + " was found"})
+	}
+}
+
+// GetSpriteFrame returns a single frame as a PNG. The frame may be given with or without a .png extension, and
+// padded=true draws the frame at its offset within the group's full size.
+func GetSpriteFrame(c *gin.Context) {
+	frameParam := strings.TrimSuffix(c.Param("frame"), ".png")
+	frameID, err := strconv.Atoi(frameParam)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid frame %s", c.Param("frame"))})
+		return
+	}
+
+	frame := SpriteFrameEntry{}
+	var pixels []byte
+	query := "SELECT sprite_id, frame, offset_x, offset_y, width, height, max_width, max_height, pixels FROM sprites WHERE sprite_id == ? AND frame == ?"
+	row := db.QueryRowContext(c, query, idParam(c, "id"), frameID)
+	err = row.Scan(&frame.SpriteID, &frame.Frame, &frame.OffsetX, &frame.OffsetY, &frame.Width, &frame.Height,
+		&frame.MaxWidth, &frame.MaxHeight, &pixels)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("No frame %d of sprite %s was found", frameID,
+			c.Param("id"))})
+		return
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for sprite ", c.Param("id"), " frame ", frameID, " : ", err)
+	}
+
+	padded := c.Query("padded") == "true"
+	etag := spriteETag(frame, pixels, padded)
+	c.Header("Cache-Control", spriteCacheControl)
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, spriteImage(frame, pixels, padded)); err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": fmt.Sprintf("Error encoding frame %d of "+
+			"sprite %s : %s", frameID, c.Param("id"), err)})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", buf.Bytes())
+}
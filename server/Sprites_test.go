@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func pixelBlob(argb ...uint32) []byte {
+	blob := make([]byte, 4*len(argb))
+	for i, pixel := range argb {
+		binary.BigEndian.PutUint32(blob[4*i:], pixel)
+	}
+	return blob
+}
+
+func TestSpriteImage(t *testing.T) {
+	type pixel struct {
+		x, y int
+		want color.NRGBA
+	}
+	frame := SpriteFrameEntry{Width: 2, Height: 1, OffsetX: 1, OffsetY: 2, MaxWidth: 4, MaxHeight: 4}
+	tests := []struct {
+		name   string
+		frame  SpriteFrameEntry
+		pixels []byte
+		padded bool
+		bounds image.Rectangle
+		want   []pixel
+	}{
+		{"opaque and transparent pixels", frame, pixelBlob(0xFFFF0000, 0), false, image.Rect(0, 0, 2, 1),
+			[]pixel{{0, 0, color.NRGBA{R: 0xFF, A: 0xFF}}, {1, 0, color.NRGBA{}}}},
+		{"partial alpha is kept", frame, pixelBlob(0x8000FF00, 0x010000FF), false, image.Rect(0, 0, 2, 1),
+			[]pixel{{0, 0, color.NRGBA{G: 0xFF, A: 0x80}}, {1, 0, color.NRGBA{B: 0xFF, A: 0x01}}}},
+		{"padded at offset", frame, pixelBlob(0xFF0000FF, 0xFF00FF00), true, image.Rect(0, 0, 4, 4),
+			[]pixel{{0, 0, color.NRGBA{}}, {1, 2, color.NRGBA{B: 0xFF, A: 0xFF}}, {2, 2, color.NRGBA{G: 0xFF, A: 0xFF}}}},
+		{"padded canvas grows to fit the frame", SpriteFrameEntry{Width: 2, Height: 1, OffsetX: 3, MaxWidth: 4,
+			MaxHeight: 1}, pixelBlob(0xFF0000FF, 0xFF0000FF), true, image.Rect(0, 0, 5, 1),
+			[]pixel{{4, 0, color.NRGBA{B: 0xFF, A: 0xFF}}}},
+		{"short pixel blob", frame, pixelBlob(0xFFFFFFFF), false, image.Rect(0, 0, 2, 1),
+			[]pixel{{0, 0, color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}}, {1, 0, color.NRGBA{}}}},
+	}
+	for _, test := range tests {
+		img := spriteImage(test.frame, test.pixels, test.padded)
+		if img.Bounds() != test.bounds {
+			t.Errorf("%s: bounds %v, want %v", test.name, img.Bounds(), test.bounds)
+		}
+		for _, p := range test.want {
+			if got := img.NRGBAAt(p.x, p.y); got != p.want {
+				t.Errorf("%s: pixel (%d, %d) = %v, want %v", test.name, p.x, p.y, got, p.want)
+			}
+		}
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{"", false},
+		{`"abc"`, true},
+		{`"def"`, false},
+		{`"def", "abc"`, true},
+		{`W/"abc"`, true},
+		{"*", true},
+	}
+	for _, test := range tests {
+		if got := etagMatches(test.ifNoneMatch, `"abc"`); got != test.want {
+			t.Errorf("etagMatches(%q) = %t, want %t", test.ifNoneMatch, got, test.want)
+		}
+	}
+}
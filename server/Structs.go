@@ -0,0 +1,95 @@
+/* Structs.go
+2024, cdfisher
+----------------
+Handlers for struct definitions, with params resolved against param_defs.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// fetchParams returns the normalized params of an entity, typed by their param definitions where known
+func fetchParams(entityType string, entityID string, c *gin.Context) []ParamEntry {
+	var output []ParamEntry
+
+	query := "SELECT p.param_id, COALESCE(d.type, ''), COALESCE(d.is_members, 0), p.int_value, p.string_value FROM params p LEFT JOIN param_defs d ON d.id = p.param_id WHERE p.entity_type == ? AND p.entity_id == ? ORDER BY p.param_id"
+	dbRows, err := db.QueryContext(c, query, entityType, entityID)
+	if err != nil {
+		log.Fatal("Error encountered executing params query for ", entityType, " ", entityID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := ParamEntry{}
+		var intVal sql.NullInt64
+		var stringVal sql.NullString
+		err = dbRows.Scan(&rowData.ID, &rowData.Type, &rowData.IsMembers, &intVal, &stringVal)
+		if err != nil {
+			fmt.Println(err)
+		}
+		if rowData.Type == "" && stringVal.Valid {
+			// No param def to go off of, but only string params have a string value
+			rowData.Value = stringVal.String
+		} else {
+			rowData.Value = typedValue(rowData.Type, intVal, stringVal)
+		}
+		output = append(output, rowData)
+	}
+
+	ids := typedIDs{}
+	for _, param := range output {
+		ids.add(param.Type, enumIntValue(param.Value))
+	}
+	names := fetchTypedNames(ids, c)
+	for i := range output {
+		output[i].ValueName = names.name(output[i].Type, enumIntValue(output[i].Value))
+	}
+	return output
+}
+
+// fetchStructEnums returns every enum entry with a struct value pointing at the given struct
+func fetchStructEnums(structID string, c *gin.Context) []StructReferenceEntry {
+	output := []StructReferenceEntry{}
+
+	query := "SELECT e.enum_id, e.key FROM enum_entries e JOIN enums n ON n.id = e.enum_id WHERE n.val_type == 'STRUCT' AND e.int_value == ? ORDER BY e.enum_id, e.key"
+	dbRows, err := db.QueryContext(c, query, structID)
+	if err != nil {
+		log.Fatal("Error encountered executing enum query for struct ", structID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := StructReferenceEntry{}
+		err = dbRows.Scan(&rowData.EnumID, &rowData.Key)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func GetStruct(c *gin.Context) {
+	structID := c.Param("id")
+
+	structDef := StructDefEntry{}
+	row := db.QueryRowContext(c, "SELECT id FROM structs WHERE id == ?", structID)
+	err := row.Scan(&structDef.ID)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No struct with id " + structID + " was found"})
+		return
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for struct ", structID, " : ", err)
+	}
+
+	structDef.Params = fetchParams("struct", structID, c)
+	structDef.Enums = fetchStructEnums(structID, c)
+
+	c.JSON(http.StatusOK, structDef)
+}
@@ -0,0 +1,46 @@
+/* Textures.go
+2024, cdfisher
+----------------
+Handlers for texture definitions and the reverse index of which entities retexture to them.
+*/
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+func GetTexture(c *gin.Context) {
+	texture := TextureDefEntry{}
+	var spriteIDs string
+
+	query := "SELECT id, sprite_ids, average_rgb, animation_direction, animation_speed FROM textures WHERE id == ?"
+	row := db.QueryRowContext(c, query, idParam(c, "id"))
+	err := row.Scan(&texture.ID, &spriteIDs, &texture.AverageRGB, &texture.AnimationDirection, &texture.AnimationSpeed)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No texture with id " + c.Param("id") + " was found"})
+		return
+	} else if err != nil {
+		log.Fatal("Error encountered executing query for texture ", c.Param("id"), " : ", err)
+	}
+	texture.SpriteIDs = json.RawMessage(spriteIDs)
+	texture.AverageColorHex = rgbToHex(texture.AverageRGB)
+
+	c.JSON(http.StatusOK, texture)
+}
+
+func GetTextureUsages(c *gin.Context) {
+	textureID := c.Param("id")
+
+	results := fetchUsages("texture_usage", "texture_id", textureID, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No entities using texture " + textureID + " were found"})
+	}
+}
@@ -0,0 +1,84 @@
+/* Usage.go
+2024, cdfisher
+----------------
+Handlers for the reverse indexes of which entities use a given model, animation, etc.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Entity types found in usage indexes and the tables their names are looked up in. Spotanims have no names.
+var UsageEntityTables = [][2]string{
+	{"item", "items"},
+	{"npc", "npcs"},
+	{"object", "objects"},
+	{"spotanim", ""},
+}
+
+// buildUsageQuery selects every usage row of a resource along with the name of the entity using it
+func buildUsageQuery(table string, idColumn string) string {
+	var joins []string
+	var names []string
+	for i, entity := range UsageEntityTables {
+		if entity[1] == "" {
+			continue
+		}
+		joins = append(joins, fmt.Sprintf("LEFT JOIN %s t%d ON u.entity_type = '%s' AND t%d.id = u.entity_id",
+			entity[1], i, entity[0], i))
+		names = append(names, fmt.Sprintf("t%d.name", i))
+	}
+	return fmt.Sprintf("SELECT u.entity_type, u.entity_id, u.role, COALESCE(%s, '') FROM %s u %s WHERE u.%s == ? ORDER BY u.entity_type, u.entity_id, u.role",
+		strings.Join(names, ", "), table, strings.Join(joins, " "), idColumn)
+}
+
+func fetchUsages(table string, idColumn string, id string, c *gin.Context) []UsageEntry {
+	var output []UsageEntry
+
+	dbRows, err := db.QueryContext(c, buildUsageQuery(table, idColumn), id)
+	if err != nil {
+		log.Fatal("Error encountered executing usage query for ", table, " ", id, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := UsageEntry{}
+		err = dbRows.Scan(&rowData.EntityType, &rowData.EntityID, &rowData.Role, &rowData.Name)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func GetModelUsages(c *gin.Context) {
+	modelID := c.Param("id")
+
+	results := fetchUsages("model_usage", "model_id", modelID, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No entities using model " + modelID + " were found"})
+	}
+}
+
+func GetAnimationUsages(c *gin.Context) {
+	animationID := c.Param("id")
+
+	results := fetchUsages("animation_usage", "animation_id", animationID, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No entities using animation " + animationID +
+			" were found"})
+	}
+}
@@ -0,0 +1,155 @@
+/* Vars.go
+2024, cdfisher
+----------------
+Handlers for varbit definitions and for looking up what depends on varbits and varps.
+
+A varbit is a range of bits packed into a varp (its base var), so its value is read out of the
+varp's value by shifting and masking.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const varbitQuery = "SELECT id, base_var, least_significant_bit, most_significant_bit FROM varbits"
+
+// valueFrom reads the varbit's value out of the value of its base varp
+func (v VarbitDefEntry) valueFrom(varpValue int32) int {
+	bits := v.MostSignificantBit - v.LeastSignificantBit + 1
+	return int((uint32(varpValue) >> v.LeastSignificantBit) & (1<<bits - 1))
+}
+
+func fetchVarbits(query string, arg string, c *gin.Context) []VarbitDefEntry {
+	var output []VarbitDefEntry
+
+	dbRows, err := db.QueryContext(c, query, arg)
+	if err != nil {
+		log.Fatal("Error encountered executing varbit query ", arg, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := VarbitDefEntry{}
+		err = dbRows.Scan(&rowData.ID, &rowData.BaseVar, &rowData.LeastSignificantBit, &rowData.MostSignificantBit)
+		if err != nil {
+			fmt.Println(err)
+		}
+		rowData.BitCount = rowData.MostSignificantBit - rowData.LeastSignificantBit + 1
+		output = append(output, rowData)
+	}
+	return output
+}
+
+func fetchVarbitByID(id int, c *gin.Context) *VarbitDefEntry {
+	results := fetchVarbits(varbitQuery+" WHERE id == ?", strconv.Itoa(id), c)
+	if len(results) == 0 {
+		return nil
+	}
+	return &results[0]
+}
+
+func GetVarbit(c *gin.Context) {
+	varbit := fetchVarbitByID(idParam(c, "id"), c)
+
+	if varbit != nil {
+		c.JSON(http.StatusOK, varbit)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No varbit with id " + c.Param("id") + " was found"})
+	}
+}
+
+// GetVarpVarbits returns every varbit packed into a varp, along with their values if a varp value is given
+func GetVarpVarbits(c *gin.Context) {
+	varpID := c.Param("id")
+
+	var varpValue int32
+	val, hasValue := c.GetQuery("value")
+	if hasValue {
+		parsed, err := strconv.ParseInt(val, 10, 32)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("Invalid varp value %s", val)})
+			return
+		}
+		varpValue = int32(parsed)
+	}
+
+	results := fetchVarbits(varbitQuery+" WHERE base_var == ? ORDER BY least_significant_bit, id", varpID, c)
+	if len(results) == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "No varbits found for varp " + varpID})
+		return
+	}
+
+	if hasValue {
+		for i := range results {
+			value := results[i].valueFrom(varpValue)
+			results[i].Value = &value
+		}
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// Tables holding each type of var dependent
+var VarDependentTables = map[string]string{"npc": "npcs", "object": "objects"}
+
+const varDependentsQuery = "SELECT d.entity_type, d.entity_id, COALESCE(n.name, o.name, ''), COALESCE(n.configs, o.config_change_dest, 'null') FROM var_dependents d LEFT JOIN npcs n ON d.entity_type == 'npc' AND n.id == d.entity_id LEFT JOIN objects o ON d.entity_type == 'object' AND o.id == d.entity_id WHERE d.var_type == ? AND d.var_id == ? ORDER BY d.entity_type, d.entity_id"
+
+func fetchVarDependents(varType string, varID string, c *gin.Context) []VarDependentEntry {
+	var output []VarDependentEntry
+	var configs [][]int
+	targetIDs := make(map[string][]int)
+
+	dbRows, err := db.QueryContext(c, varDependentsQuery, varType, varID)
+	if err != nil {
+		log.Fatal("Error encountered executing query for ", varType, " ", varID, " : ", err)
+	}
+	defer dbRows.Close()
+
+	for dbRows.Next() {
+		rowData := VarDependentEntry{}
+		var configText string
+		err = dbRows.Scan(&rowData.EntityType, &rowData.ID, &rowData.Name, &configText)
+		if err != nil {
+			fmt.Println(err)
+		}
+		output = append(output, rowData)
+		configs = append(configs, parseIntSlice(configText))
+		targetIDs[rowData.EntityType] = append(targetIDs[rowData.EntityType], configs[len(configs)-1]...)
+	}
+
+	// Name every morph target with one query per table rather than one per target
+	names := make(map[string]map[int]string, len(targetIDs))
+	for entityType, ids := range targetIDs {
+		names[entityType] = fetchNames(VarDependentTables[entityType], ids, c)
+	}
+	for i := range output {
+		output[i].Morphs = buildMorphTargets(configs[i], names[output[i].EntityType])
+	}
+	return output
+}
+
+func getVarDependents(c *gin.Context, varType string) {
+	varID := c.Param("id")
+
+	results := fetchVarDependents(varType, varID, c)
+
+	if len(results) > 0 {
+		c.JSON(http.StatusOK, results)
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("No NPCs or objects depend on %s %s",
+			varType, varID)})
+	}
+}
+
+func GetVarbitDependents(c *gin.Context) {
+	getVarDependents(c, "varbit")
+}
+
+func GetVarpDependents(c *gin.Context) {
+	getVarDependents(c, "varp")
+}
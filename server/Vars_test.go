@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetVarDependents(t *testing.T) {
+	openTestDB(t,
+		"INSERT INTO npcs (id, name, varbit_id, varp_index, configs) VALUES (100, 'Shapeshifter', 1234, -1, '[101,-1,102]'), (101, 'Shapeshifter (bird)', -1, -1, 'null'), (102, 'Shapeshifter (wolf)', -1, -1, 'null')",
+		"INSERT INTO objects (id, name, varbit_id, varp_id, config_change_dest) VALUES (101, 'Crate', -1, -1, 'null'), (500, 'Gate', 1234, -1, '[501,502]'), (501, 'Gate (open)', -1, -1, 'null'), (502, 'Gate (closed)', -1, -1, 'null')",
+		"INSERT INTO var_dependents VALUES ('varbit', 1234, 'npc', 100), ('varbit', 1234, 'object', 500)",
+	)
+
+	recorder := serve(http.MethodGet, "/varbits/1234/dependents", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", recorder.Code, recorder.Body)
+	}
+	var got []VarDependentEntry
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	// NPC 101 and object 101 share an id, so each target must be named from its own table
+	want := []VarDependentEntry{
+		{EntityType: "npc", ID: 100, Name: "Shapeshifter", Morphs: []MorphTarget{
+			{Value: 0, ID: 101, Name: "Shapeshifter (bird)"},
+			{Value: 1, ID: -1},
+			{Value: -1, Default: true, ID: 102, Name: "Shapeshifter (wolf)"},
+		}},
+		{EntityType: "object", ID: 500, Name: "Gate", Morphs: []MorphTarget{
+			{Value: 0, ID: 501, Name: "Gate (open)"},
+			{Value: -1, Default: true, ID: 502, Name: "Gate (closed)"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	if recorder = serve(http.MethodGet, "/varps/1234/dependents", ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("varp with no dependents: got status %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestVarbitValueFrom(t *testing.T) {
+	tests := []struct {
+		lsb, msb  int
+		varpValue int32
+		want      int
+	}{
+		{0, 0, 0b1, 1},
+		{0, 0, 0b10, 0},
+		{4, 6, 0b1010000, 0b101},
+		{0, 3, 0b11110101, 0b0101},
+		// Varps are signed, so the top bits of negative values must still be read as set
+		{31, 31, -1 << 31, 1},
+		{0, 31, -1, 0xFFFFFFFF},
+		{16, 31, -2, 0xFFFF},
+	}
+	for _, test := range tests {
+		varbit := VarbitDefEntry{LeastSignificantBit: test.lsb, MostSignificantBit: test.msb}
+		if got := varbit.valueFrom(test.varpValue); got != test.want {
+			t.Errorf("bits %d-%d of %b = %b, want %b", test.lsb, test.msb, test.varpValue, got, test.want)
+		}
+	}
+}